@@ -1,6 +1,8 @@
 package args
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/pflag"
@@ -10,35 +12,162 @@ import (
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
-// Get fetches the remaining arguments after CLI parsing and splits them into
-// two groups: the arguments before the double dash (--) and the arguments after
-// the double dash.
-func Get() ([]string, []string, error) {
-	args := pflag.Args()
-	doubleDashPos := pflag.CommandLine.ArgsLenAtDash()
+// Split splits the command line arguments into the ones for the flags of fs,
+// followed by the double dash (--) and the arguments after it when given, and
+// the other arguments in their order: task names, variables and the arguments
+// of tasks. Flags of fs win over the flags declared by tasks, which is why
+// tasks can't declare them, see [task.WithReservedFlags].
+func Split(fs *pflag.FlagSet, arguments []string) ([]string, []string) {
+	var flagArgs, taskArgs []string
+	for i := 0; i < len(arguments); i++ {
+		arg := arguments[i]
+		var known, takesNext bool
+		switch {
+		case arg == "--":
+			return append(flagArgs, arguments[i:]...), taskArgs
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			if flag := fs.Lookup(name); flag != nil {
+				known, takesNext = true, !hasValue && flag.NoOptDefVal == ""
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			known, takesNext = shorthands(fs, arg[1:])
+		}
+		if !known {
+			taskArgs = append(taskArgs, arg)
+			continue
+		}
+		flagArgs = append(flagArgs, arg)
+		if takesNext && i+1 < len(arguments) {
+			i++
+			flagArgs = append(flagArgs, arguments[i])
+		}
+	}
+	return flagArgs, taskArgs
+}
+
+// shorthands reports whether s, e.g. "vf" for -vf, starts with a shorthand
+// flag of fs and whether the last flag takes its value from the next argument.
+func shorthands(fs *pflag.FlagSet, s string) (bool, bool) {
+	for i := range len(s) {
+		flag := fs.ShorthandLookup(s[i : i+1])
+		if flag == nil {
+			return i > 0, false
+		}
+		if flag.NoOptDefVal == "" {
+			// The value is either the rest of s or the next argument
+			return true, i == len(s)-1
+		}
+	}
+	return true, false
+}
 
+// Get fetches the arguments after the double dash (--) once the flags of Task
+// are parsed. The arguments before it are split from the flags by [Split].
+func Get() ([]string, error) {
+	doubleDashPos := pflag.CommandLine.ArgsLenAtDash()
 	if doubleDashPos == -1 {
-		return args, nil, nil
+		return nil, nil
 	}
-	return args[:doubleDashPos], args[doubleDashPos:], nil
+	return pflag.Args()[doubleDashPos:], nil
 }
 
-// Parse parses command line argument: tasks and global variables
-func Parse(args ...string) ([]*task.Call, *ast.Vars) {
+// Parse parses command line arguments: tasks, the args they declare and global
+// variables. Words and --flags after a task bind to the args of that task,
+// which are returned by lookup, and are set in the vars of its call. Once its
+// positional args are bound, the next word starts another call.
+func Parse(lookup func(task string) []*ast.Arg, args ...string) ([]*task.Call, *ast.Vars, error) {
 	calls := []*task.Call{}
 	globals := ast.NewVars()
+	origin := &ast.VarOrigin{Layer: ast.VarLayerCLI}
+	argOrigin := &ast.VarOrigin{Layer: ast.VarLayerArg}
 
-	for _, arg := range args {
-		if !strings.Contains(arg, "=") {
-			calls = append(calls, &task.Call{Task: arg})
+	var (
+		call       *task.Call
+		positional []*ast.Arg
+		flags      []*ast.Arg
+	)
+	set := func(arg *ast.Arg, value any, origin *ast.VarOrigin) {
+		if call.Vars == nil {
+			call.Vars = ast.NewVars()
+		}
+		call.Vars.Set(arg.Name, ast.Var{Value: value, Secret: arg.Secret, Origin: origin})
+	}
+	// Flags that were not given take their default
+	finish := func() {
+		for _, arg := range flags {
+			if _, ok := call.Vars.Get(arg.Name); !ok && arg.HasDefault() {
+				set(arg, arg.Default, &ast.VarOrigin{Layer: ast.VarLayerDefault, Location: arg.Location})
+			}
+		}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if strings.HasPrefix(arg, "--") && len(arg) > 2 {
+			if call == nil {
+				return nil, nil, fmt.Errorf("task: unknown flag %q", arg)
+			}
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			j := slices.IndexFunc(flags, func(a *ast.Arg) bool { return a.Flag == name })
+			if j == -1 {
+				return nil, nil, fmt.Errorf("task: unknown flag %q for task %q", arg, call.Task)
+			}
+			switch {
+			case hasValue:
+			case flags[j].Type == ast.RequiredVarTypeBool:
+				value = "true"
+			case i+1 < len(args):
+				i++
+				value = args[i]
+			default:
+				return nil, nil, fmt.Errorf("task: flag %q of task %q needs a value", arg, call.Task)
+			}
+			set(flags[j], value, argOrigin)
 			continue
 		}
 
-		name, value := splitVar(arg)
-		globals.Set(name, ast.Var{Value: value})
+		// Words starting with a dash, like negative numbers, are only
+		// accepted as the value of a positional arg
+		if strings.HasPrefix(arg, "-") && len(arg) > 1 && len(positional) == 0 {
+			return nil, nil, fmt.Errorf("task: unknown flag %q", arg)
+		}
+
+		// A pending positional arg takes the word as is, even with an =
+		if len(positional) > 0 {
+			set(positional[0], arg, argOrigin)
+			positional = positional[1:]
+			continue
+		}
+
+		if strings.Contains(arg, "=") {
+			name, value := splitVar(arg)
+			globals.Set(name, ast.Var{Value: value, Origin: origin})
+			continue
+		}
+
+		if call != nil {
+			finish()
+		}
+		call = &task.Call{Task: arg}
+		calls = append(calls, call)
+		positional, flags = nil, nil
+		if lookup != nil {
+			for _, a := range lookup(arg) {
+				if a.IsFlag() {
+					flags = append(flags, a)
+				} else {
+					positional = append(positional, a)
+				}
+			}
+		}
+	}
+	if call != nil {
+		finish()
 	}
 
-	return calls, globals
+	return calls, globals, nil
 }
 
 func ToQuotedString(args []string) (string, error) {
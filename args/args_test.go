@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/go-task/task/v3"
 	"github.com/go-task/task/v3/args"
@@ -14,6 +16,8 @@ import (
 func TestArgs(t *testing.T) {
 	t.Parallel()
 
+	cli := &ast.VarOrigin{Layer: ast.VarLayerCLI}
+
 	tests := []struct {
 		Args            []string
 		ExpectedCalls   []*task.Call
@@ -38,19 +42,22 @@ func TestArgs(t *testing.T) {
 				&ast.VarElement{
 					Key: "FOO",
 					Value: ast.Var{
-						Value: "bar",
+						Value:  "bar",
+						Origin: cli,
 					},
 				},
 				&ast.VarElement{
 					Key: "BAR",
 					Value: ast.Var{
-						Value: "baz",
+						Value:  "baz",
+						Origin: cli,
 					},
 				},
 				&ast.VarElement{
 					Key: "BAZ",
 					Value: ast.Var{
-						Value: "foo",
+						Value:  "foo",
+						Origin: cli,
 					},
 				},
 			),
@@ -64,7 +71,8 @@ func TestArgs(t *testing.T) {
 				&ast.VarElement{
 					Key: "CONTENT",
 					Value: ast.Var{
-						Value: "with some spaces",
+						Value:  "with some spaces",
+						Origin: cli,
 					},
 				},
 			),
@@ -79,7 +87,8 @@ func TestArgs(t *testing.T) {
 				&ast.VarElement{
 					Key: "FOO",
 					Value: ast.Var{
-						Value: "bar",
+						Value:  "bar",
+						Origin: cli,
 					},
 				},
 			),
@@ -99,13 +108,15 @@ func TestArgs(t *testing.T) {
 				&ast.VarElement{
 					Key: "FOO",
 					Value: ast.Var{
-						Value: "bar",
+						Value:  "bar",
+						Origin: cli,
 					},
 				},
 				&ast.VarElement{
 					Key: "BAR",
 					Value: ast.Var{
-						Value: "baz",
+						Value:  "baz",
+						Origin: cli,
 					},
 				},
 			),
@@ -116,7 +127,8 @@ func TestArgs(t *testing.T) {
 		t.Run(fmt.Sprintf("TestArgs%d", i+1), func(t *testing.T) {
 			t.Parallel()
 
-			calls, globals := args.Parse(test.Args...)
+			calls, globals, err := args.Parse(nil, test.Args...)
+			require.NoError(t, err)
 			assert.Equal(t, test.ExpectedCalls, calls)
 			if test.ExpectedGlobals.Len() > 0 || globals.Len() > 0 {
 				assert.Equal(t, test.ExpectedGlobals, globals)
@@ -125,3 +137,147 @@ func TestArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestArgsWithTaskArgs(t *testing.T) {
+	t.Parallel()
+
+	deployArgs := []*ast.Arg{
+		{VarsWithValidation: ast.VarsWithValidation{Name: "ENV"}},
+		{VarsWithValidation: ast.VarsWithValidation{Name: "REGION", Default: "us"}, Flag: "region"},
+		{VarsWithValidation: ast.VarsWithValidation{Name: "CANARY", Type: ast.RequiredVarTypeBool, Default: false}, Flag: "canary"},
+	}
+	lookup := func(name string) []*ast.Arg {
+		if name == "deploy" {
+			return deployArgs
+		}
+		return nil
+	}
+	arg := &ast.VarOrigin{Layer: ast.VarLayerArg}
+	defaultOrigin := &ast.VarOrigin{Layer: ast.VarLayerDefault}
+	v := func(key string, value any, origin *ast.VarOrigin) *ast.VarElement {
+		return &ast.VarElement{Key: key, Value: ast.Var{Value: value, Origin: origin}}
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedCalls []*task.Call
+		expectedErr   string
+	}{
+		{
+			name: "positional and flags",
+			args: []string{"deploy", "prod", "--region", "eu", "--canary"},
+			expectedCalls: []*task.Call{
+				{Task: "deploy", Vars: ast.NewVars(v("ENV", "prod", arg), v("REGION", "eu", arg), v("CANARY", "true", arg))},
+			},
+		},
+		{
+			name: "flags before positional with equal sign",
+			args: []string{"deploy", "--region=eu", "prod", "--canary=false"},
+			expectedCalls: []*task.Call{
+				{Task: "deploy", Vars: ast.NewVars(v("REGION", "eu", arg), v("ENV", "prod", arg), v("CANARY", "false", arg))},
+			},
+		},
+		{
+			name: "separate calls",
+			args: []string{"deploy", "prod", "--region", "eu", "deploy", "dev", "lint"},
+			expectedCalls: []*task.Call{
+				{Task: "deploy", Vars: ast.NewVars(v("ENV", "prod", arg), v("REGION", "eu", arg), v("CANARY", false, defaultOrigin))},
+				{Task: "deploy", Vars: ast.NewVars(v("ENV", "dev", arg), v("REGION", "us", defaultOrigin), v("CANARY", false, defaultOrigin))},
+				{Task: "lint"},
+			},
+		},
+		{
+			name: "positional with equal sign",
+			args: []string{"deploy", "a=b", "FOO=bar"},
+			expectedCalls: []*task.Call{
+				{Task: "deploy", Vars: ast.NewVars(v("ENV", "a=b", arg), v("REGION", "us", defaultOrigin), v("CANARY", false, defaultOrigin))},
+			},
+		},
+		{
+			name: "missing positional",
+			args: []string{"deploy", "--canary"},
+			expectedCalls: []*task.Call{
+				{Task: "deploy", Vars: ast.NewVars(v("CANARY", "true", arg), v("REGION", "us", defaultOrigin))},
+			},
+		},
+		{
+			name:        "unknown flag",
+			args:        []string{"deploy", "prod", "--zone", "a"},
+			expectedErr: `task: unknown flag "--zone" for task "deploy"`,
+		},
+		{
+			name:        "flag without task",
+			args:        []string{"--region", "eu"},
+			expectedErr: `task: unknown flag "--region"`,
+		},
+		{
+			name:        "flag without value",
+			args:        []string{"deploy", "prod", "--region"},
+			expectedErr: `task: flag "--region" of task "deploy" needs a value`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls, _, err := args.Parse(lookup, test.args...)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	newFlagSet := func() *pflag.FlagSet {
+		fs := pflag.NewFlagSet("task", pflag.ContinueOnError)
+		fs.BoolP("verbose", "v", false, "")
+		fs.BoolP("force", "f", false, "")
+		fs.StringP("dir", "d", "", "")
+		fs.IntP("concurrency", "C", 0, "")
+		return fs
+	}
+
+	tests := []struct {
+		name             string
+		args             []string
+		expectedFlags    []string
+		expectedTaskArgs []string
+	}{
+		{
+			name:             "task flags",
+			args:             []string{"deploy", "-v", "prod", "--region", "eu", "--dir", "sub", "-C", "2"},
+			expectedFlags:    []string{"-v", "--dir", "sub", "-C", "2"},
+			expectedTaskArgs: []string{"deploy", "prod", "--region", "eu"},
+		},
+		{
+			name:             "combined shorthands and values",
+			args:             []string{"-vf", "-dsub", "--dir=sub", "build", "-x"},
+			expectedFlags:    []string{"-vf", "-dsub", "--dir=sub"},
+			expectedTaskArgs: []string{"build", "-x"},
+		},
+		{
+			name:             "double dash",
+			args:             []string{"build", "--canary", "--", "--region", "-v"},
+			expectedFlags:    []string{"--", "--region", "-v"},
+			expectedTaskArgs: []string{"build", "--canary"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			flagArgs, taskArgs := args.Split(newFlagSet(), test.args)
+			assert.Equal(t, test.expectedFlags, flagArgs)
+			assert.Equal(t, test.expectedTaskArgs, taskArgs)
+		})
+	}
+}
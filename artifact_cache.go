@@ -0,0 +1,113 @@
+package task
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/go-task/task/v3/internal/artifactcache"
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// setupArtifactCache connects to the artifact cache, if one is configured. A
+// cache that can't be set up is only reported in verbose mode, since the build
+// must never depend on it.
+func (e *Executor) setupArtifactCache() {
+	if e.ArtifactCacheURL == "" {
+		return
+	}
+	client, err := taskfile.BuildHTTPClient(e.Insecure, e.CACert, e.Cert, e.CertKey)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: artifact cache disabled: %v\n", err)
+		return
+	}
+	cache, err := artifactcache.NewHTTPCache(e.ArtifactCacheURL, e.ArtifactCacheMode, client)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: artifact cache disabled: %v\n", err)
+		return
+	}
+	e.artifactCache = cache
+}
+
+// artifactCacheKey returns the key under which the generated files of t are
+// cached, or false if the task can't be cached. Only tasks with both sources
+// and generates are. The key is always derived from the contents of the
+// sources, so that it is stable across machines, from the definition of the
+// task, so that changing its cmds, vars or env doesn't restore stale files,
+// and from the platform, since the generated files may only work on it.
+func (e *Executor) artifactCacheKey(ctx context.Context, t *ast.Task) (string, bool) {
+	if e.artifactCache == nil || e.Dry {
+		return "", false
+	}
+	if len(t.Sources) == 0 || len(t.Generates) == 0 || e.fingerprinter().Kind(t) == "none" {
+		return "", false
+	}
+	value, err := fingerprint.NewChecksumChecker(e.TempDir.Fingerprint, true, e.fingerprintCache).Value(ctx, t)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache skipped: %v\n", t.Name(), err)
+		return "", false
+	}
+	checksum, _ := value.(string)
+	definition, err := fingerprint.DefinitionHash(t)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache skipped: %v\n", t.Name(), err)
+		return "", false
+	}
+	return artifactcache.Key(t.Name(), checksum, definition, runtime.GOOS+"/"+runtime.GOARCH), true
+}
+
+// restoreFromArtifactCache extracts the generated files of t from the artifact
+// cache. It reports whether they were restored, in which case the task doesn't
+// need to run.
+func (e *Executor) restoreFromArtifactCache(ctx context.Context, t *ast.Task) bool {
+	key, ok := e.artifactCacheKey(ctx, t)
+	if !ok {
+		return false
+	}
+	data, ok, err := e.artifactCache.Get(ctx, key)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache error: %v\n", t.Name(), err)
+		return false
+	}
+	if !ok {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache miss: %s\n", t.Name(), key)
+		return false
+	}
+	if err := e.mkdir(t); err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache error: %v\n", t.Name(), err)
+		return false
+	}
+	if err := artifactcache.Unpack(t.Dir, data); err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache error: %v\n", t.Name(), err)
+		return false
+	}
+	e.Logger.VerboseErrf(logger.Magenta, "task: [%s] artifact cache hit: %s\n", t.Name(), key)
+	return true
+}
+
+// storeInArtifactCache uploads the generated files of t after a successful run.
+func (e *Executor) storeInArtifactCache(ctx context.Context, t *ast.Task) {
+	if e.artifactCache == nil || !e.artifactCache.Writable() {
+		return
+	}
+	key, ok := e.artifactCacheKey(ctx, t)
+	if !ok {
+		return
+	}
+	generates, err := fingerprint.Globs(t.Dir, t.Generates, false)
+	if err != nil || len(generates) == 0 {
+		return
+	}
+	data, err := artifactcache.Pack(t.Dir, generates)
+	if err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache upload skipped: %v\n", t.Name(), err)
+		return
+	}
+	if err := e.artifactCache.Put(ctx, key, data); err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: [%s] artifact cache error: %v\n", t.Name(), err)
+		return
+	}
+	e.Logger.VerboseErrf(logger.Magenta, "task: [%s] artifact cache upload: %s\n", t.Name(), key)
+}
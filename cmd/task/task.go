@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 
 	"github.com/spf13/pflag"
+	"go.yaml.in/yaml/v3"
 
 	"github.com/go-task/task/v3"
 	"github.com/go-task/task/v3/args"
@@ -78,7 +80,7 @@ func run() error {
 		return nil
 	}
 
-	if flags.Help {
+	if flags.Help && len(flags.Args) == 0 {
 		pflag.Usage()
 		return nil
 	}
@@ -92,13 +94,9 @@ func run() error {
 		if err != nil {
 			return err
 		}
-		args, _, err := args.Get()
-		if err != nil {
-			return err
-		}
 		path := wd
-		if len(args) > 0 {
-			name := args[0]
+		if len(flags.Args) > 0 {
+			name := flags.Args[0]
 			if filepathext.IsExtOnly(name) {
 				name = filepathext.SmartJoin(filepath.Dir(name), "Taskfile"+filepath.Ext(name))
 			}
@@ -130,6 +128,13 @@ func run() error {
 		flags.WithFlags(),
 		task.WithVersionCheck(true),
 	)
+
+	// The remote cache is managed without reading the Taskfile, which could
+	// prompt for the very Taskfiles being trusted
+	if flags.Remote != "" {
+		return e.ManageRemoteCache(flags.Remote, flags.ListJson, flags.Args...)
+	}
+
 	if err := e.Setup(); err != nil {
 		return err
 	}
@@ -139,6 +144,24 @@ func run() error {
 		return os.RemoveAll(cachePath)
 	}
 
+	// The Taskfile.lock was written while setting up the executor
+	if flags.Lock {
+		return nil
+	}
+
+	if flags.ResetState != "" {
+		if err := e.ResetState(flags.ResetState); err != nil {
+			return err
+		}
+		if !flags.PruneState {
+			return nil
+		}
+	}
+
+	if flags.PruneState {
+		return e.PruneState()
+	}
+
 	listOptions := task.NewListOptions(
 		flags.List,
 		flags.ListAll,
@@ -160,12 +183,30 @@ func run() error {
 		return nil
 	}
 
+	if flags.ListArgs {
+		return e.ListTaskArgs(flags.Args...)
+	}
+
+	if flags.Help {
+		calls, _, err := args.Parse(e.TaskArgs, flags.Args...)
+		if err != nil {
+			return err
+		}
+		return e.ShowTaskHelp(flags.ListJson, flags.NoStatus, calls...)
+	}
+
 	// Parse the remaining arguments
-	cliArgsPreDash, cliArgsPostDash, err := args.Get()
+	cliArgsPostDash, err := args.Get()
+	if err != nil {
+		return err
+	}
+	calls, globals, err := args.Parse(e.TaskArgs, flags.Args...)
 	if err != nil {
 		return err
 	}
-	calls, globals := args.Parse(cliArgsPreDash...)
+	if (flags.Eval != "" || flags.EvalVars) && len(calls) > 0 {
+		return errors.New("task: --eval and --vars take the task from --task")
+	}
 
 	// If there are no calls, run the default task instead
 	if len(calls) == 0 {
@@ -181,14 +222,36 @@ func run() error {
 		return err
 	}
 	specialVars := ast.NewVars()
-	specialVars.Set("CLI_ARGS", ast.Var{Value: cliArgsPostDashQuoted})
-	specialVars.Set("CLI_ARGS_LIST", ast.Var{Value: cliArgsPostDash})
-	specialVars.Set("CLI_FORCE", ast.Var{Value: flags.Force || flags.ForceAll})
-	specialVars.Set("CLI_SILENT", ast.Var{Value: flags.Silent})
-	specialVars.Set("CLI_VERBOSE", ast.Var{Value: flags.Verbose})
-	specialVars.Set("CLI_OFFLINE", ast.Var{Value: flags.Offline})
-	specialVars.Set("CLI_ASSUME_YES", ast.Var{Value: flags.AssumeYes})
+	specialOrigin := &ast.VarOrigin{Layer: ast.VarLayerSpecial}
+	specialVars.Set("CLI_ARGS", ast.Var{Value: cliArgsPostDashQuoted, Origin: specialOrigin})
+	specialVars.Set("CLI_ARGS_LIST", ast.Var{Value: cliArgsPostDash, Origin: specialOrigin})
+	specialVars.Set("CLI_FORCE", ast.Var{Value: flags.Force || flags.ForceAll, Origin: specialOrigin})
+	specialVars.Set("CLI_SILENT", ast.Var{Value: flags.Silent, Origin: specialOrigin})
+	specialVars.Set("CLI_VERBOSE", ast.Var{Value: flags.Verbose, Origin: specialOrigin})
+	specialVars.Set("CLI_OFFLINE", ast.Var{Value: flags.Offline, Origin: specialOrigin})
+	specialVars.Set("CLI_ASSUME_YES", ast.Var{Value: flags.AssumeYes, Origin: specialOrigin})
 	e.Taskfile.Vars.ReverseMerge(specialVars, nil)
+
+	if flags.Eval != "" || flags.EvalVars {
+		var call *task.Call
+		if flags.EvalTask != "" {
+			call = &task.Call{Task: flags.EvalTask}
+		}
+		if flags.Eval != "" {
+			result, err := e.Eval(call, flags.Eval)
+			if err != nil {
+				return err
+			}
+			fmt.Println(result)
+			return nil
+		}
+		vars, err := e.ResolvedVars(call)
+		if err != nil {
+			return err
+		}
+		return printVars(vars, flags.ListJson)
+	}
+
 	if !flags.Watch {
 		e.InterceptInterruptSignals()
 	}
@@ -201,3 +264,14 @@ func run() error {
 
 	return e.Run(ctx, calls...)
 }
+
+// printVars prints the values of vars, along with their origins and the ones
+// they overrode.
+func printVars(vars map[string]*task.ResolvedVar, asJson bool) error {
+	if asJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(vars)
+	}
+	return yaml.NewEncoder(os.Stdout).Encode(vars)
+}
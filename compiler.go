@@ -51,25 +51,29 @@ func (c *Compiler) getVariables(t *ast.Task, call *Call, evaluateShVars bool) (*
 	if err != nil {
 		return nil, err
 	}
+	specialOrigin := &ast.VarOrigin{Layer: ast.VarLayerSpecial}
 	for k, v := range specialVars {
-		result.Set(k, ast.Var{Value: v, Secret: false})
+		result.Set(k, ast.Var{Value: v, Secret: false, Origin: specialOrigin})
 	}
 
-	getRangeFunc := func(dir string) func(k string, v ast.Var) error {
-		return func(k string, v ast.Var) error {
-			cache := &templater.Cache{Vars: result}
+	getRangeFunc := func(dir string) func(k string, v ast.Var, layer string) error {
+		return func(k string, v ast.Var, layer string) error {
+			// Record the layer of the variable and the one it overrides
+			prev, _ := result.Get(k)
+			v = v.WithOrigin(layer, prev.Origin)
+			cache := &templater.Cache{Vars: result, Dir: dir}
 			// Replace values
 			newVar := templater.ReplaceVar(v, cache)
 			// If the variable should not be evaluated, but is nil, set it to an empty string
 			// This stops empty interface errors when using the templater to replace values later
 			// Preserve the Sh field so it can be displayed in summary
 			if !evaluateShVars && newVar.Value == nil {
-				result.Set(k, ast.Var{Value: "", Sh: newVar.Sh, Secret: v.Secret})
+				result.Set(k, ast.Var{Value: "", Sh: newVar.Sh, Secret: v.Secret, Origin: v.Origin})
 				return nil
 			}
 			// If the variable should not be evaluated and it is set, we can set it and return
 			if !evaluateShVars {
-				result.Set(k, ast.Var{Value: newVar.Value, Sh: newVar.Sh, Secret: v.Secret})
+				result.Set(k, ast.Var{Value: newVar.Value, Sh: newVar.Sh, Secret: v.Secret, Origin: v.Origin})
 				return nil
 			}
 			// Now we can check for errors since we've handled all the cases when we don't want to evaluate
@@ -78,7 +82,7 @@ func (c *Compiler) getVariables(t *ast.Task, call *Call, evaluateShVars bool) (*
 			}
 			// If the variable is already set, we can set it and return
 			if newVar.Value != nil || newVar.Sh == nil {
-				result.Set(k, ast.Var{Value: newVar.Value, Secret: v.Secret})
+				result.Set(k, ast.Var{Value: newVar.Value, Secret: v.Secret, Origin: v.Origin})
 				return nil
 			}
 			// If the variable is dynamic, we need to resolve it first
@@ -86,13 +90,13 @@ func (c *Compiler) getVariables(t *ast.Task, call *Call, evaluateShVars bool) (*
 			if err != nil {
 				return err
 			}
-			result.Set(k, ast.Var{Value: static, Secret: v.Secret})
+			result.Set(k, ast.Var{Value: static, Secret: v.Secret, Origin: v.Origin})
 			return nil
 		}
 	}
 	rangeFunc := getRangeFunc(c.Dir)
 
-	var taskRangeFunc func(k string, v ast.Var) error
+	var taskRangeFunc func(k string, v ast.Var, layer string) error
 	if t != nil {
 		// NOTE(@andreynering): We're manually joining these paths here because
 		// this is the raw task, not the compiled one.
@@ -106,23 +110,23 @@ func (c *Compiler) getVariables(t *ast.Task, call *Call, evaluateShVars bool) (*
 	}
 
 	for k, v := range c.TaskfileEnv.All() {
-		if err := rangeFunc(k, v); err != nil {
+		if err := rangeFunc(k, v, ast.VarLayerTaskfile); err != nil {
 			return nil, err
 		}
 	}
 	for k, v := range c.TaskfileVars.All() {
-		if err := rangeFunc(k, v); err != nil {
+		if err := rangeFunc(k, v, ast.VarLayerTaskfile); err != nil {
 			return nil, err
 		}
 	}
 	if t != nil {
 		for k, v := range t.IncludeVars.All() {
-			if err := rangeFunc(k, v); err != nil {
+			if err := rangeFunc(k, v, ast.VarLayerInclude); err != nil {
 				return nil, err
 			}
 		}
 		for k, v := range t.IncludedTaskfileVars.All() {
-			if err := taskRangeFunc(k, v); err != nil {
+			if err := taskRangeFunc(k, v, ast.VarLayerIncludedTaskfile); err != nil {
 				return nil, err
 			}
 		}
@@ -133,12 +137,12 @@ func (c *Compiler) getVariables(t *ast.Task, call *Call, evaluateShVars bool) (*
 	}
 
 	for k, v := range call.Vars.All() {
-		if err := rangeFunc(k, v); err != nil {
+		if err := rangeFunc(k, v, ast.VarLayerCall); err != nil {
 			return nil, err
 		}
 	}
 	for k, v := range t.Vars.All() {
-		if err := taskRangeFunc(k, v); err != nil {
+		if err := taskRangeFunc(k, v, ast.VarLayerTask); err != nil {
 			return nil, err
 		}
 	}
@@ -229,7 +233,7 @@ func (c *Compiler) getSpecialVars(t *ast.Task, call *Call) (map[string]string, e
 		allVars["TASK"] = t.Task
 		if taskfile.IsRemoteEntrypoint(t.Location.Taskfile) {
 			allVars["TASKFILE"] = t.Location.Taskfile
-			allVars["TASKFILE_DIR"] = ""
+			allVars["TASKFILE_DIR"] = filepath.ToSlash(t.TaskfileDir)
 			switch {
 			case t.Dir == "":
 				allVars["TASK_DIR"] = filepath.ToSlash(c.UserWorkingDir)
@@ -242,6 +246,10 @@ func (c *Compiler) getSpecialVars(t *ast.Task, call *Call) (map[string]string, e
 			allVars["TASK_DIR"] = filepath.ToSlash(filepathext.SmartJoin(c.Dir, t.Dir))
 			allVars["TASKFILE"] = filepath.ToSlash(t.Location.Taskfile)
 			allVars["TASKFILE_DIR"] = filepath.ToSlash(filepath.Dir(t.Location.Taskfile))
+			// Taskfiles of archives only exist where the archive was extracted
+			if taskfile.IsArchiveEntrypoint(t.Location.Taskfile) {
+				allVars["TASKFILE_DIR"] = filepath.ToSlash(t.TaskfileDir)
+			}
 		}
 	} else {
 		allVars["TASK"] = ""
@@ -41,10 +41,11 @@ function _task()
     ;;
   esac
 
-  # Handle normal options.
+  # Handle normal options and the flags declared by the tasks given so far.
   case "$cur" in
     -*)
-      COMPREPLY=( $( compgen -W "$(_parse_help $1)" -- $cur ) )
+      local task_flags=$( "${words[@]:0:$cword}" --list-args 2> /dev/null )
+      COMPREPLY=( $( compgen -W "$(_parse_help $1) $task_flags" -- $cur ) )
       return 0
     ;;
   esac
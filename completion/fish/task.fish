@@ -60,11 +60,20 @@ function __task_get_tasks --description "Prints all available tasks with their d
   end
 end
 
+function __task_get_task_flags --description "Prints the flags declared by the tasks on the command line" --inherit-variable GO_TASK_PROGNAME
+  commandline --current-process --cut-at-cursor | read --tokenize --list --local cmd_args
+  $GO_TASK_PROGNAME $cmd_args[2..] --list-args 2>/dev/null
+end
+
 complete -c $GO_TASK_PROGNAME \
   -d 'Runs the specified task(s). Falls back to the "default" task if no task name was specified, or lists all tasks if an unknown task name was specified.' \
   -xa "(__task_get_tasks)" \
   -n "not __fish_seen_subcommand_from --"
 
+# Flags declared by the tasks
+complete -c $GO_TASK_PROGNAME -a "(__task_get_task_flags)" \
+  -n "not __fish_seen_subcommand_from --; and string match -q -- '--*' (commandline --current-token)"
+
 # Standard flags
 complete -c $GO_TASK_PROGNAME -s a -l list-all                  -d 'list all tasks'
 complete -c $GO_TASK_PROGNAME -s c -l color                     -d 'colored output (default true)'
@@ -110,6 +119,9 @@ complete -c $GO_TASK_PROGNAME      -l cert                      -d 'client certi
 complete -c $GO_TASK_PROGNAME      -l cert-key                  -d 'client certificate private key' -r
 complete -c $GO_TASK_PROGNAME      -l download                  -d 'download remote Taskfile'
 complete -c $GO_TASK_PROGNAME      -l clear-cache               -d 'clear remote Taskfile cache'
+complete -c $GO_TASK_PROGNAME      -l remote                    -d 'manage remote Taskfile cache' -xa "list show prune trust untrust"
+complete -c $GO_TASK_PROGNAME      -l lock                      -d 'write Taskfile.lock of remote Taskfiles'
+complete -c $GO_TASK_PROGNAME      -l update-lock               -d 'accept remote Taskfiles that drifted from Taskfile.lock'
 
 # Experimental flags (dynamically checked at completion time via -n condition)
 # GentleForce experiment
@@ -72,7 +72,10 @@ Register-ArgumentCompleter -CommandName $cmdNames -ScriptBlock {
 			[CompletionResult]::new('--cert', '--cert', [CompletionResultType]::ParameterName, 'client certificate'),
 			[CompletionResult]::new('--cert-key', '--cert-key', [CompletionResultType]::ParameterName, 'client private key'),
 			[CompletionResult]::new('--download', '--download', [CompletionResultType]::ParameterName, 'download remote Taskfile'),
-			[CompletionResult]::new('--clear-cache', '--clear-cache', [CompletionResultType]::ParameterName, 'clear cache')
+			[CompletionResult]::new('--clear-cache', '--clear-cache', [CompletionResultType]::ParameterName, 'clear cache'),
+			[CompletionResult]::new('--remote', '--remote', [CompletionResultType]::ParameterName, 'manage remote cache'),
+			[CompletionResult]::new('--lock', '--lock', [CompletionResultType]::ParameterName, 'write Taskfile.lock'),
+			[CompletionResult]::new('--update-lock', '--update-lock', [CompletionResultType]::ParameterName, 'update Taskfile.lock')
 		)
 
 		# Experimental flags (dynamically added based on enabled experiments)
@@ -27,6 +27,8 @@ const (
 	CodeTaskfileInvalid
 	CodeTaskfileCycle
 	CodeTaskfileDoesNotMatchChecksum
+	CodeTaskfileLockMismatch
+	CodeTaskfileSignatureInvalid
 )
 
 // Task related exit codes
@@ -40,6 +42,7 @@ const (
 	CodeTaskMissingRequiredVars
 	CodeTaskNotAllowedVars
 	CodeTaskTimedOut
+	CodeTaskInvalidVars
 )
 
 // TaskError extends the standard error interface with a Code method. This code will
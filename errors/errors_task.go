@@ -228,3 +228,41 @@ func (err *TaskNotAllowedVarsError) Error() string {
 func (err *TaskNotAllowedVarsError) Code() int {
 	return CodeTaskNotAllowedVars
 }
+
+// InvalidVar is a required variable whose value doesn't match its type,
+// pattern or bounds.
+type InvalidVar struct {
+	Name     string
+	Value    string
+	Reason   string
+	Location string
+	Snippet  string
+}
+
+// TaskInvalidVarsError is returned when the required variables of a task have
+// invalid values.
+type TaskInvalidVarsError struct {
+	TaskName    string
+	InvalidVars []InvalidVar
+}
+
+func (err *TaskInvalidVarsError) Error() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "task: Task %q cancelled because it has invalid required variables:\n", err.TaskName)
+	for _, v := range err.InvalidVars {
+		fmt.Fprintf(&builder, "  - %s has an invalid value '%s': %s\n", v.Name, v.Value, v.Reason)
+		if v.Location != "" {
+			fmt.Fprintf(&builder, "    required at %s\n", v.Location)
+		}
+		if v.Snippet != "" {
+			fmt.Fprintln(&builder, v.Snippet)
+		}
+	}
+
+	return builder.String()
+}
+
+func (err *TaskInvalidVarsError) Code() int {
+	return CodeTaskInvalidVars
+}
@@ -216,3 +216,53 @@ func (err *TaskfileDoesNotMatchChecksum) Error() string {
 func (err *TaskfileDoesNotMatchChecksum) Code() int {
 	return CodeTaskfileDoesNotMatchChecksum
 }
+
+// TaskfileLockMismatchError is returned when a remote Taskfile drifted from the
+// one recorded in the Taskfile.lock, or is not recorded in it at all.
+type TaskfileLockMismatchError struct {
+	URI string
+	// Field is what drifted, e.g. "checksum" or "commit". Empty when the
+	// Taskfile is not in the lockfile.
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (err *TaskfileLockMismatchError) Error() string {
+	if err.Field == "" {
+		return fmt.Sprintf(
+			"task: The remote Taskfile at %q is not in the Taskfile.lock. Run again with --update-lock to add it",
+			filepath.ToSlash(err.URI),
+		)
+	}
+	return fmt.Sprintf(
+		"task: The %s of the remote Taskfile at %q does not match the Taskfile.lock!\ngot: %q\nwant: %q\nRun again with --update-lock to accept the change",
+		err.Field,
+		filepath.ToSlash(err.URI),
+		err.Actual,
+		err.Expected,
+	)
+}
+
+func (err *TaskfileLockMismatchError) Code() int {
+	return CodeTaskfileLockMismatch
+}
+
+// TaskfileSignatureInvalidError is returned when the detached signature of a
+// remote Taskfile is not valid or was not made by a trusted key.
+type TaskfileSignatureInvalidError struct {
+	URI    string
+	Reason string
+}
+
+func (err *TaskfileSignatureInvalidError) Error() string {
+	return fmt.Sprintf(
+		"task: The signature of the remote Taskfile at %q is invalid: %s",
+		filepath.ToSlash(err.URI),
+		err.Reason,
+	)
+}
+
+func (err *TaskfileSignatureInvalidError) Code() int {
+	return CodeTaskfileSignatureInvalid
+}
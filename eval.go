@@ -0,0 +1,80 @@
+package task
+
+import (
+	"os"
+
+	"github.com/go-task/task/v3/internal/templater"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// Eval renders the template with the vars of the task called by call,
+// compiled like for running it, including the vars of the call and the MATCH
+// of wildcards. With a nil call, only the vars of the Taskfile are available.
+func (e *Executor) Eval(call *Call, template string) (string, error) {
+	vars, dir, err := e.evalVars(call)
+	if err != nil {
+		return "", err
+	}
+	cache := &templater.Cache{Vars: vars, Dir: dir}
+	result := templater.Replace(template, cache)
+	return result, cache.Err()
+}
+
+// ResolvedVar is the value of a var resolved by [Executor.ResolvedVars], with
+// the origin of the value and the origins of the values it overrode.
+type ResolvedVar struct {
+	Value     any      `json:"value" yaml:"value"`
+	Origin    string   `json:"origin,omitempty" yaml:"origin,omitempty"`
+	Overrides []string `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// ResolvedVars returns the vars available to the task called by call,
+// compiled like [Executor.Eval]. The values of secret vars are masked, and the
+// environment variables are left out unless a var overrides them.
+func (e *Executor) ResolvedVars(call *Call) (map[string]*ResolvedVar, error) {
+	vars, _, err := e.evalVars(call)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]*ResolvedVar, vars.Len())
+	for name, v := range vars.All() {
+		if environ, ok := os.LookupEnv(name); ok && v.Value == any(environ) {
+			continue
+		}
+		r := &ResolvedVar{Value: v.Value}
+		if v.Secret {
+			r.Value = "*****"
+		}
+		if v.Origin != nil {
+			r.Origin = v.Origin.String()
+			for _, o := range v.Origin.Chain()[1:] {
+				r.Overrides = append(r.Overrides, o.String())
+			}
+		}
+		resolved[name] = r
+	}
+	return resolved, nil
+}
+
+// evalVars returns the vars of the task called by call and the directory of
+// the task, or the ones of the Taskfile for a nil call.
+func (e *Executor) evalVars(call *Call) (*ast.Vars, string, error) {
+	if call == nil {
+		vars, err := e.Compiler.GetVariables(nil, nil)
+		return vars, e.Dir, err
+	}
+
+	t, err := e.GetTask(call)
+	if err != nil {
+		return nil, "", err
+	}
+	vars, err := e.Compiler.GetVariables(t, call)
+	if err != nil {
+		return nil, "", err
+	}
+	dir, err := e.taskDir(t, &templater.Cache{Vars: vars})
+	if err != nil {
+		return nil, "", err
+	}
+	return vars, dir, nil
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/puzpuzpuz/xsync/v4"
 	"github.com/sajari/fuzzy"
 
+	"github.com/go-task/task/v3/internal/artifactcache"
 	"github.com/go-task/task/v3/internal/fingerprint"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/output"
 	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/taskfile"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -35,13 +37,19 @@ type (
 		Insecure            bool
 		Download            bool
 		Offline             bool
+		UpdateLock          bool
 		TrustedHosts        []string
+		TrustedKeys         []string
 		Timeout             time.Duration
 		CacheExpiryDuration time.Duration
 		RemoteCacheDir      string
 		CACert              string
 		Cert                string
 		CertKey             string
+		RemoteAuth          map[string]*taskfile.HTTPAuth
+		ReservedFlags       []string
+		ArtifactCacheURL    string
+		ArtifactCacheMode   string
 		Watch               bool
 		Verbose             bool
 		Silent              bool
@@ -82,6 +90,9 @@ type (
 		executionHashes      map[string]*executionState
 		executionHashesMutex sync.Mutex
 		watchedDirs          *xsync.Map[string, bool]
+		netrc                *taskfile.Netrc
+		artifactCache        *artifactcache.HTTPCache
+		fingerprintCache     *fingerprint.Cache
 	}
 	TempDir struct {
 		Remote      string
@@ -130,6 +141,8 @@ func (e *Executor) fingerprinter() *fingerprint.Fingerprinter {
 		e.TempDir.Fingerprint,
 		e.Dry,
 		e.Logger,
+		fingerprint.WithCache(e.fingerprintCache),
+		fingerprint.WithMethods(e.Taskfile.Methods),
 	)
 }
 
@@ -263,6 +276,21 @@ func (o *offlineOption) ApplyToExecutor(e *Executor) {
 	e.Offline = o.offline
 }
 
+// WithUpdateLock makes the [Executor] download fresh copies of the remote
+// Taskfiles and record them in the Taskfile.lock, instead of failing when they
+// drifted from it.
+func WithUpdateLock(updateLock bool) ExecutorOption {
+	return &updateLockOption{updateLock}
+}
+
+type updateLockOption struct {
+	updateLock bool
+}
+
+func (o *updateLockOption) ApplyToExecutor(e *Executor) {
+	e.UpdateLock = o.updateLock
+}
+
 // WithTrustedHosts configures the [Executor] with a list of trusted hosts for remote
 // Taskfiles. Hosts in this list will not prompt for user confirmation.
 func WithTrustedHosts(trustedHosts []string) ExecutorOption {
@@ -277,6 +305,21 @@ func (o *trustedHostsOption) ApplyToExecutor(e *Executor) {
 	e.TrustedHosts = o.trustedHosts
 }
 
+// WithTrustedKeys configures the [Executor] with the public keys remote
+// Taskfiles can be signed with. Taskfiles with a valid signature from one of
+// these keys will not prompt for user confirmation.
+func WithTrustedKeys(trustedKeys []string) ExecutorOption {
+	return &trustedKeysOption{trustedKeys}
+}
+
+type trustedKeysOption struct {
+	trustedKeys []string
+}
+
+func (o *trustedKeysOption) ApplyToExecutor(e *Executor) {
+	e.TrustedKeys = o.trustedKeys
+}
+
 // WithTimeout sets the [Executor]'s timeout for fetching remote taskfiles. By
 // default, the timeout is set to 10 seconds.
 func WithTimeout(timeout time.Duration) ExecutorOption {
@@ -357,6 +400,65 @@ func (o *certKeyOption) ApplyToExecutor(e *Executor) {
 	e.CertKey = o.certKey
 }
 
+// WithRemoteAuth sets the credentials sent to the hosts of remote Taskfiles,
+// keyed by host.
+func WithRemoteAuth(auth map[string]*taskfile.HTTPAuth) ExecutorOption {
+	return &remoteAuthOption{auth: auth}
+}
+
+type remoteAuthOption struct {
+	auth map[string]*taskfile.HTTPAuth
+}
+
+func (o *remoteAuthOption) ApplyToExecutor(e *Executor) {
+	e.RemoteAuth = o.auth
+}
+
+// WithReservedFlags sets the names of the flags of the program running the
+// [Executor]. They are parsed before the Taskfile is read, so the args of tasks
+// can't declare them.
+func WithReservedFlags(flags []string) ExecutorOption {
+	return &reservedFlagsOption{flags: flags}
+}
+
+type reservedFlagsOption struct {
+	flags []string
+}
+
+func (o *reservedFlagsOption) ApplyToExecutor(e *Executor) {
+	e.ReservedFlags = o.flags
+}
+
+// WithArtifactCacheURL sets the base URL of a shared HTTP cache from which the
+// [Executor] restores the generated files of stale tasks instead of running
+// them. By default, no artifact cache is used.
+func WithArtifactCacheURL(url string) ExecutorOption {
+	return &artifactCacheURLOption{url: url}
+}
+
+type artifactCacheURLOption struct {
+	url string
+}
+
+func (o *artifactCacheURLOption) ApplyToExecutor(e *Executor) {
+	e.ArtifactCacheURL = o.url
+}
+
+// WithArtifactCacheMode sets whether the [Executor] only reads from the
+// artifact cache ("read-only") or also uploads the generated files of the tasks
+// it runs ("read-write"). By default, the cache is read-write.
+func WithArtifactCacheMode(mode string) ExecutorOption {
+	return &artifactCacheModeOption{mode: mode}
+}
+
+type artifactCacheModeOption struct {
+	mode string
+}
+
+func (o *artifactCacheModeOption) ApplyToExecutor(e *Executor) {
+	e.ArtifactCacheMode = o.mode
+}
+
 // WithWatch tells the [Executor] to keep running in the background and watch
 // for changes to the fingerprint of the tasks that are run. When changes are
 // detected, a new task run is triggered.
@@ -449,6 +449,48 @@ func TestRequires(t *testing.T) {
 		WithVar("ENV", "dev"),
 		WithRunError(),
 	)
+	NewExecutorTest(t,
+		WithName("typed - passes validation"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("validation-var-typed"),
+		WithVar("REPLICAS", "3"),
+		WithVar("VERSION", "v1.2.3"),
+		WithVar("DRY_RUN", "true"),
+		WithVar("ENDPOINT", "https://example.com/api"),
+		WithVar("CONFIG", "Taskfile.yml"),
+		WithVar("REGIONS", "eu, us"),
+	)
+	NewExecutorTest(t,
+		WithName("typed - fails validation"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("validation-var-typed"),
+		WithVar("REPLICAS", "30"),
+		WithVar("VERSION", "1.2"),
+		WithVar("DRY_RUN", "maybe"),
+		WithVar("ENDPOINT", "example.com"),
+		WithVar("CONFIG", "missing.yml"),
+		WithVar("REGIONS", "eu,us,ap"),
+		WithRunError(),
+	)
+	NewExecutorTest(t,
+		WithName("defaults - used when missing"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("defaults"),
+	)
+	NewExecutorTest(t,
+		WithName("defaults - overridden"),
+		WithExecutorOptions(
+			task.WithDir("testdata/requires"),
+		),
+		WithTask("defaults"),
+		WithVar("ENV", "prod"),
+	)
 }
 
 // TODO: mock fs
@@ -765,6 +807,26 @@ func TestSummaryWithVarsAndRequires(t *testing.T) {
 		),
 		WithTask("with-sh-var"),
 	)
+
+	// Test with descriptions and defaults of required variables
+	NewExecutorTest(t,
+		WithName("requires-defaults"),
+		WithExecutorOptions(
+			task.WithDir("testdata/summary-vars-requires"),
+			task.WithSummary(true),
+		),
+		WithTask("with-defaults"),
+	)
+
+	// Test with positional and flag arguments
+	NewExecutorTest(t,
+		WithName("args"),
+		WithExecutorOptions(
+			task.WithDir("testdata/summary-vars-requires"),
+			task.WithSummary(true),
+		),
+		WithTask("with-args"),
+	)
 }
 
 func TestLabel(t *testing.T) {
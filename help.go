@@ -14,6 +14,7 @@ import (
 	"github.com/go-task/task/v3/internal/editors"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/internal/summary"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -136,6 +137,75 @@ func (e *Executor) ListTaskNames(allTasks bool) error {
 	return nil
 }
 
+// ShowTaskHelp prints the help of the tasks of the given calls, or their
+// editor output as JSON if asJson is true. Checking whether the tasks are up to
+// date is skipped if noStatus is true.
+func (e *Executor) ShowTaskHelp(asJson bool, noStatus bool, calls ...*Call) error {
+	tasks := make([]*ast.Task, len(calls))
+	for i, call := range calls {
+		t, err := e.FastCompiledTask(call)
+		if err != nil {
+			return err
+		}
+		tasks[i] = t
+	}
+
+	if asJson {
+		output, err := e.ToEditorOutput(tasks, noStatus, false)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(e.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	for i, t := range tasks {
+		var upToDate *bool
+		if !noStatus {
+			isUpToDate, err := e.fingerprinter().UpToDate(context.Background(), t)
+			if err != nil {
+				return err
+			}
+			upToDate = &isUpToDate
+		}
+		summary.PrintSpaceBetweenSummaries(e.Logger, i)
+		summary.PrintTaskHelp(e.Logger, t, upToDate)
+	}
+	return nil
+}
+
+// TaskArgs returns the args declared by the task called name, or nil if there
+// is no such task.
+func (e *Executor) TaskArgs(name string) []*ast.Arg {
+	matchingTasks, err := e.FindMatchingTasks(&Call{Task: name})
+	if err != nil || len(matchingTasks) == 0 {
+		return nil
+	}
+	return matchingTasks[0].Task.Args
+}
+
+// ListTaskArgs prints the flags declared by the given tasks, one per line, to
+// complete them in shells. Names that are not tasks are ignored.
+func (e *Executor) ListTaskArgs(names ...string) error {
+	// use stdout if no output defined
+	var w io.Writer = os.Stdout
+	if e.Stdout != nil {
+		w = e.Stdout
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range names {
+		for _, arg := range e.TaskArgs(name) {
+			if arg.IsFlag() && !seen[arg.Flag] {
+				seen[arg.Flag] = true
+				fmt.Fprintf(w, "--%s\n", arg.Flag)
+			}
+		}
+	}
+	return nil
+}
+
 func (e *Executor) ToEditorOutput(tasks []*ast.Task, noStatus bool, nested bool) (*editors.Namespace, error) {
 	var g errgroup.Group
 	editorTasks := make([]editors.Task, len(tasks))
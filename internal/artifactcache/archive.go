@@ -0,0 +1,160 @@
+package artifactcache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Key derives the cache key of a task from its name and fingerprints, like the
+// ones of its sources and definition. It is a hex encoded SHA-256, which is
+// what bazel-remote expects.
+func Key(taskName string, fingerprints ...string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "task\x00%s", taskName)
+	for _, fingerprint := range fingerprints {
+		fmt.Fprintf(h, "\x00%s", fingerprint)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Pack archives the given files as a gzipped tarball. Their names are stored
+// relative to dir, which must contain all of them.
+func Pack(dir string, files []string) ([]byte, error) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	for _, f := range files {
+		rel, err := filepath.Rel(dir, f)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsLocal(rel) {
+			return nil, fmt.Errorf("task: %q is outside of %q", f, dir)
+		}
+		if err := addFile(tw, f, filepath.ToSlash(rel)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func addFile(tw *tar.Writer, path string, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// Unpack extracts an archive created by [Pack] into dir. Entries that would
+// escape dir are rejected. The files are extracted aside first, so that a
+// failure leaves none of them behind.
+func Unpack(dir string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(dir, ".artifact-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	names, err := unpack(tmp, data)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(tmp, name), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpack extracts the archive data into dir, and returns the names of the
+// extracted files.
+func unpack(dir string, data []byte) ([]string, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			slices.Sort(names)
+			return slices.Compact(names), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) || strings.Contains(header.Name, `\`) {
+			return nil, fmt.Errorf("task: invalid path %q in artifact", header.Name)
+		}
+		if err := extractFile(tr, filepath.Join(dir, name), os.FileMode(header.Mode).Perm()); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+}
+
+func extractFile(r io.Reader, path string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,94 @@
+package artifactcache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpack(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "bin"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "bin", "app"), []byte("app"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "out.txt"), []byte("out"), 0o644))
+
+	data, err := Pack(src, []string{filepath.Join(src, "bin", "app"), filepath.Join(src, "out.txt")})
+	require.NoError(t, err)
+
+	dst := t.TempDir()
+	require.NoError(t, Unpack(dst, data))
+
+	b, err := os.ReadFile(filepath.Join(dst, "bin", "app"))
+	require.NoError(t, err)
+	assert.Equal(t, "app", string(b))
+	b, err = os.ReadFile(filepath.Join(dst, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "out", string(b))
+}
+
+func TestPackOutsideDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := Pack(filepath.Join(dir, "sub"), []string{filepath.Join(dir, "file.txt")})
+	assert.Error(t, err)
+}
+
+func TestUnpackRejectsTraversal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../evil.txt", Mode: 0o644, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	dir := t.TempDir()
+	assert.Error(t, Unpack(filepath.Join(dir, "sub"), buf.Bytes()))
+	_, err = os.Stat(filepath.Join(dir, "evil.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnpackFailureLeavesNothing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "bin/app", Mode: 0o755, Size: 3}))
+	_, err := tw.Write([]byte("app"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../evil.txt", Mode: 0o644, Size: 4}))
+	_, err = tw.Write([]byte("evil"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	dir := t.TempDir()
+	require.Error(t, Unpack(dir, buf.Bytes()))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, Key("build", "abc"), 64)
+	assert.Equal(t, Key("build", "abc"), Key("build", "abc"))
+	assert.NotEqual(t, Key("build", "abc"), Key("build", "abd"))
+	assert.NotEqual(t, Key("build", "abc"), Key("test", "abc"))
+	assert.NotEqual(t, Key("build", "abc", "linux/amd64"), Key("build", "abc", "darwin/arm64"))
+	assert.NotEqual(t, Key("build", "ab", "c"), Key("build", "a", "bc"))
+}
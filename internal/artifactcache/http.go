@@ -0,0 +1,126 @@
+package artifactcache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// ModeReadOnly only downloads artifacts from the cache.
+	ModeReadOnly = "read-only"
+	// ModeReadWrite downloads artifacts from the cache and uploads the ones
+	// produced by tasks that had to run.
+	ModeReadWrite = "read-write"
+)
+
+// maxArtifactSize bounds the size of a downloaded artifact, so that a
+// misbehaving server can't exhaust the memory.
+const maxArtifactSize = 1 << 30
+
+// An HTTPCache stores artifacts on a server speaking the plain HTTP cache
+// protocol used by bazel-remote and the Gradle build cache: an artifact is
+// read with a GET and written with a PUT on the base URL followed by its key.
+type HTTPCache struct {
+	url     *url.URL
+	mode    string
+	client  *http.Client
+	maxSize int64
+}
+
+// NewHTTPCache creates a cache rooted at rawURL. An empty mode defaults to
+// [ModeReadWrite].
+func NewHTTPCache(rawURL string, mode string, client *http.Client) (*HTTPCache, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("task: unsupported artifact cache URL scheme %q", u.Scheme)
+	}
+	switch mode {
+	case "":
+		mode = ModeReadWrite
+	case ModeReadOnly, ModeReadWrite:
+	default:
+		return nil, fmt.Errorf("task: invalid artifact cache mode %q", mode)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPCache{
+		url:     u,
+		mode:    mode,
+		client:  client,
+		maxSize: maxArtifactSize,
+	}, nil
+}
+
+// Location returns the base URL of the cache with any credentials redacted.
+func (c *HTTPCache) Location() string {
+	return c.url.Redacted()
+}
+
+// Writable reports whether artifacts may be uploaded to the cache.
+func (c *HTTPCache) Writable() bool {
+	return c.mode == ModeReadWrite
+}
+
+// Get downloads the artifact stored under key. A missing artifact is not an
+// error: ok is false and data is nil.
+func (c *HTTPCache) Get(ctx context.Context, key string) (data []byte, ok bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.JoinPath(key).String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("task: unexpected status %q from artifact cache", resp.Status)
+	}
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, c.maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > c.maxSize {
+		return nil, false, fmt.Errorf("task: artifact %q is larger than %d bytes", key, c.maxSize)
+	}
+	return data, true, nil
+}
+
+// Put uploads data under key. It does nothing on a read-only cache.
+func (c *HTTPCache) Put(ctx context.Context, key string, data []byte) error {
+	if !c.Writable() {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url.JoinPath(key).String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("task: unexpected status %q from artifact cache", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package artifactcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPCacheGet(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cache/found" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(strings.Repeat("a", 16)))
+	}))
+	defer srv.Close()
+
+	cache, err := NewHTTPCache(srv.URL+"/cache", "", srv.Client())
+	require.NoError(t, err)
+
+	data, ok, err := cache.Get(t.Context(), "found")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, data, 16)
+
+	_, ok, err = cache.Get(t.Context(), "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Artifacts larger than the maximum size are rejected
+	cache.maxSize = 8
+	_, _, err = cache.Get(t.Context(), "found")
+	assert.ErrorContains(t, err, "larger than 8 bytes")
+}
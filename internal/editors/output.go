@@ -13,13 +13,31 @@ type (
 	}
 	// Task describes a single task
 	Task struct {
-		Name     string    `json:"name"`
-		Task     string    `json:"task"`
-		Desc     string    `json:"desc"`
-		Summary  string    `json:"summary"`
-		Aliases  []string  `json:"aliases"`
-		UpToDate *bool     `json:"up_to_date,omitempty"`
-		Location *Location `json:"location"`
+		Name      string        `json:"name"`
+		Task      string        `json:"task"`
+		Desc      string        `json:"desc"`
+		Summary   string        `json:"summary"`
+		Aliases   []string      `json:"aliases"`
+		UpToDate  *bool         `json:"up_to_date,omitempty"`
+		Location  *Location     `json:"location"`
+		Requires  []RequiredVar `json:"requires,omitempty"`
+		Args      []Arg         `json:"args,omitempty"`
+		Deps      []string      `json:"deps,omitempty"`
+		Platforms []string      `json:"platforms,omitempty"`
+	}
+	// RequiredVar describes a variable required by a task
+	RequiredVar struct {
+		Name    string   `json:"name"`
+		Desc    string   `json:"desc,omitempty"`
+		Type    string   `json:"type,omitempty"`
+		Default any      `json:"default,omitempty"`
+		Example string   `json:"example,omitempty"`
+		Enum    []string `json:"enum,omitempty"`
+	}
+	// Arg describes an argument of a task given on the command line
+	Arg struct {
+		RequiredVar
+		Flag string `json:"flag,omitempty"`
 	}
 	// Location describes a task's location in a taskfile
 	Location struct {
@@ -45,7 +63,71 @@ func NewTask(task *ast.Task) Task {
 			Column:   task.Location.Column,
 			Taskfile: task.Location.Taskfile,
 		},
+		Requires:  newRequiredVars(task.Requires),
+		Args:      newArgs(task.Args),
+		Deps:      newDeps(task.Deps),
+		Platforms: newPlatforms(task.Platforms),
+	}
+}
+
+func newDeps(deps []*ast.Dep) []string {
+	var names []string
+	for _, dep := range deps {
+		if dep.Task != "" {
+			names = append(names, dep.Task)
+		}
+	}
+	return names
+}
+
+func newPlatforms(platforms []*ast.Platform) []string {
+	var names []string
+	for _, p := range platforms {
+		names = append(names, p.String())
+	}
+	return names
+}
+
+func newRequiredVars(requires *ast.Requires) []RequiredVar {
+	if requires == nil || len(requires.Vars) == 0 {
+		return nil
+	}
+	vars := make([]RequiredVar, len(requires.Vars))
+	for i, v := range requires.Vars {
+		vars[i] = newRequiredVar(v)
+	}
+	return vars
+}
+
+func newArgs(args []*ast.Arg) []Arg {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]Arg, len(args))
+	for i, arg := range args {
+		out[i] = Arg{
+			RequiredVar: newRequiredVar(&arg.VarsWithValidation),
+			Flag:        arg.Flag,
+		}
+	}
+	return out
+}
+
+func newRequiredVar(v *ast.VarsWithValidation) RequiredVar {
+	rv := RequiredVar{
+		Name:    v.Name,
+		Desc:    v.Desc,
+		Type:    v.Type,
+		Default: v.Default,
+		Example: v.Example,
+	}
+	if v.Secret && v.HasDefault() {
+		rv.Default = "*****"
+	}
+	if v.Enum != nil {
+		rv.Enum = v.Enum.Value
 	}
+	return rv
 }
 
 func (parent *Namespace) AddNamespace(namespacePath []string, task Task) {
@@ -17,10 +17,11 @@ const taskVarPrefix = "TASK_"
 // ast.Vars
 func GetEnviron() *ast.Vars {
 	m := ast.NewVars()
+	origin := &ast.VarOrigin{Layer: ast.VarLayerEnvironment}
 	for _, e := range os.Environ() {
 		keyVal := strings.SplitN(e, "=", 2)
 		key, val := keyVal[0], keyVal[1]
-		m.Set(key, ast.Var{Value: val})
+		m.Set(key, ast.Var{Value: val, Origin: origin})
 	}
 	return m
 }
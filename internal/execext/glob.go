@@ -0,0 +1,40 @@
+package execext
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/fsext"
+)
+
+// Glob returns the files matching the pattern, relative patterns being
+// resolved from dir. Directories are left out, and the paths are sorted and
+// use forward slashes.
+func Glob(dir string, pattern string) ([]string, error) {
+	pattern = filepathext.SmartJoin(dir, pattern)
+
+	if results, ok, err := fsext.FastRecursiveGlob(pattern); ok {
+		return results, err
+	}
+
+	fs, err := ExpandFields(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, 0, len(fs))
+	for _, f := range fs {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			continue
+		}
+		results = append(results, filepath.ToSlash(f))
+	}
+	slices.Sort(results)
+	return slices.Compact(results), nil
+}
@@ -0,0 +1,265 @@
+package fingerprint
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeebo/xxh3"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-task/task/v3/internal/execext"
+)
+
+// racyWindow is how recent a modification has to be for the hash of a file not
+// to be cached: a file written again within the resolution of its mtime would
+// otherwise keep a stale hash.
+const racyWindow = 2 * time.Second
+
+type (
+	// A Cache shares fingerprinting work between the tasks of a run. It
+	// memoizes glob expansions until [Cache.InvalidateGlobs] is called, and
+	// keeps the hashes of source files keyed by their path, size, mtime and
+	// inode so that unchanged files are not read again. The hashes are
+	// persisted in the temp dir by [Cache.Save].
+	// All methods are safe to call on a nil Cache, which caches nothing.
+	Cache struct {
+		path string
+		dry  bool
+
+		globsMutex sync.Mutex
+		globs      map[string][]string
+
+		hashesOnce  sync.Once
+		hashesMutex sync.Mutex
+		hashes      map[string]fileHash
+		dirty       bool
+	}
+
+	fileHash struct {
+		Size  int64
+		MTime int64
+		Inode uint64
+		Hash  string
+	}
+)
+
+// NewCache creates a cache whose file hashes are persisted in tempDir.
+// When dry is true, [Cache.Save] does not write anything.
+func NewCache(tempDir string, dry bool) *Cache {
+	return &Cache{
+		path:  filepath.Join(tempDir, "file-hashes"),
+		dry:   dry,
+		globs: map[string][]string{},
+	}
+}
+
+// InvalidateGlobs forgets the memoized glob expansions. It must be called
+// whenever files may have been created or deleted, like after a task ran.
+func (c *Cache) InvalidateGlobs() {
+	if c == nil {
+		return
+	}
+	c.globsMutex.Lock()
+	defer c.globsMutex.Unlock()
+	clear(c.globs)
+}
+
+func (c *Cache) glob(dir string, g string) ([]string, error) {
+	if c == nil {
+		return execext.Glob(dir, g)
+	}
+	key := dir + "\x00" + g
+
+	c.globsMutex.Lock()
+	matches, ok := c.globs[key]
+	c.globsMutex.Unlock()
+	if ok {
+		return matches, nil
+	}
+
+	matches, err := execext.Glob(dir, g)
+	if err != nil {
+		return nil, err
+	}
+	c.globsMutex.Lock()
+	c.globs[key] = matches
+	c.globsMutex.Unlock()
+	return matches, nil
+}
+
+// hashFiles returns the hashes of the given files, in the same order. They
+// are checked by a bounded number of workers, which only read the files that
+// changed since they were last hashed.
+func (c *Cache) hashFiles(files []string) ([]string, error) {
+	hashes := make([]string, len(files))
+
+	var next atomic.Int64
+	g := &errgroup.Group{}
+	for range min(runtime.GOMAXPROCS(0), len(files)) {
+		g.Go(func() error {
+			var buf []byte
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(files) {
+					return nil
+				}
+				info, err := os.Stat(files[i])
+				if err != nil {
+					return err
+				}
+				if h, ok := c.lookup(files[i], info); ok {
+					hashes[i] = h
+					continue
+				}
+				if buf == nil {
+					buf = make([]byte, 128*1024)
+				}
+				h, err := hashFile(files[i], buf)
+				if err != nil {
+					return err
+				}
+				hashes[i] = h
+				c.store(files[i], info, h)
+			}
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+func (c *Cache) lookup(path string, info os.FileInfo) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.hashesOnce.Do(c.load)
+
+	c.hashesMutex.Lock()
+	defer c.hashesMutex.Unlock()
+	entry, ok := c.hashes[path]
+	if !ok || entry.Size != info.Size() || entry.MTime != info.ModTime().UnixNano() || entry.Inode != inode(info) {
+		return "", false
+	}
+	return entry.Hash, true
+}
+
+func (c *Cache) store(path string, info os.FileInfo, hash string) {
+	if c == nil || time.Since(info.ModTime()) < racyWindow {
+		return
+	}
+	c.hashesOnce.Do(c.load)
+
+	c.hashesMutex.Lock()
+	defer c.hashesMutex.Unlock()
+	c.hashes[path] = fileHash{
+		Size:  info.Size(),
+		MTime: info.ModTime().UnixNano(),
+		Inode: inode(info),
+		Hash:  hash,
+	}
+	c.dirty = true
+}
+
+// load reads the persisted hashes. A missing or unreadable file is the same as
+// an empty cache.
+func (c *Cache) load() {
+	c.hashes = map[string]fileHash{}
+
+	file, err := os.Open(c.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	var hashes map[string]fileHash
+	if err := gob.NewDecoder(file).Decode(&hashes); err == nil && hashes != nil {
+		c.hashes = hashes
+	}
+}
+
+// Save persists the file hashes if any of them changed. The entries of files
+// that no longer exist are kept until [PruneFileHashes] drops them, so saving
+// doesn't stat every cached path.
+func (c *Cache) Save() error {
+	if c == nil || c.dry {
+		return nil
+	}
+	c.hashesMutex.Lock()
+	defer c.hashesMutex.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(tmp).Encode(c.hashes); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// PruneFileHashes removes the hashes of the files that no longer exist from
+// the cache persisted in tempDir, and returns their paths. With dry, the cache
+// is left untouched.
+func PruneFileHashes(tempDir string, dry bool) ([]string, error) {
+	c := NewCache(tempDir, dry)
+	c.hashesOnce.Do(c.load)
+
+	var paths []string
+	for path := range c.hashes {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			paths = append(paths, path)
+			delete(c.hashes, path)
+			c.dirty = true
+		}
+	}
+	slices.Sort(paths)
+	return paths, c.Save()
+}
+
+// readerOnly hides any WriterTo/ReaderFrom implementation of the wrapped
+// reader, forcing io.CopyBuffer to use the caller-provided buffer.
+type readerOnly struct{ io.Reader }
+
+func hashFile(path string, buf []byte) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := xxh3.New()
+	// Wrap the file in a plain io.Reader so io.CopyBuffer cannot take the
+	// (*os.File).WriteTo fast path, which ignores buf and allocates a fresh
+	// 32KiB buffer for every file.
+	if _, err := io.CopyBuffer(h, readerOnly{file}, buf); err != nil {
+		return "", err
+	}
+	sum := h.Sum128()
+	return fmt.Sprintf("%x%x", sum.Hi, sum.Lo), nil
+}
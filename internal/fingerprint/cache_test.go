@@ -0,0 +1,119 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheHashFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o644))
+	// Make the file old enough for its hash to be cached
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file, old, old))
+
+	cache := NewCache(filepath.Join(dir, ".task"), false)
+	hashes, err := cache.hashFiles([]string{file})
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	require.NoError(t, cache.Save())
+
+	// A new cache loads the persisted hash
+	cache = NewCache(filepath.Join(dir, ".task"), false)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	h, ok := cache.lookup(file, info)
+	assert.True(t, ok)
+	assert.Equal(t, hashes[0], h)
+
+	// Changing the file invalidates its hash
+	require.NoError(t, os.WriteFile(file, []byte("b"), 0o644))
+	info, err = os.Stat(file)
+	require.NoError(t, err)
+	_, ok = cache.lookup(file, info)
+	assert.False(t, ok)
+
+	changed, err := cache.hashFiles([]string{file})
+	require.NoError(t, err)
+	assert.NotEqual(t, hashes[0], changed[0])
+}
+
+func TestPruneFileHashes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tempDir := filepath.Join(dir, ".task")
+	kept := filepath.Join(dir, "kept.txt")
+	removed := filepath.Join(dir, "removed.txt")
+	old := time.Now().Add(-time.Hour)
+	for _, file := range []string{kept, removed} {
+		require.NoError(t, os.WriteFile(file, []byte(file), 0o644))
+		require.NoError(t, os.Chtimes(file, old, old))
+	}
+
+	cache := NewCache(tempDir, false)
+	_, err := cache.hashFiles([]string{kept, removed})
+	require.NoError(t, err)
+	require.NoError(t, cache.Save())
+	require.NoError(t, os.Remove(removed))
+
+	// With dry, the hashes are only listed
+	paths, err := PruneFileHashes(tempDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{removed}, paths)
+
+	paths, err = PruneFileHashes(tempDir, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{removed}, paths)
+
+	cache = NewCache(tempDir, false)
+	cache.hashesOnce.Do(cache.load)
+	assert.Contains(t, cache.hashes, kept)
+	assert.NotContains(t, cache.hashes, removed)
+}
+
+func TestCacheGlobs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0o644))
+
+	cache := NewCache(filepath.Join(dir, ".task"), false)
+	files, err := cache.glob(dir, "*.txt")
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	// The expansion is memoized until the globs are invalidated
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), nil, 0o644))
+	files, err = cache.glob(dir, "*.txt")
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	cache.InvalidateGlobs()
+	files, err = cache.glob(dir, "*.txt")
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestNilCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o644))
+
+	var cache *Cache
+	hashes, err := cache.hashFiles([]string{file})
+	require.NoError(t, err)
+	assert.Len(t, hashes, 1)
+	cache.InvalidateGlobs()
+	assert.NoError(t, cache.Save())
+}
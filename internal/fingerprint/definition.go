@@ -0,0 +1,125 @@
+package fingerprint
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// volatileVars are set by Task itself. They change between runs, machines or
+// working directories without the task itself changing, so they are never part
+// of a task's definition. CLI_* vars are left out as well.
+var volatileVars = map[string]bool{
+	"TASK":                true,
+	"TASK_DIR":            true,
+	"TASK_EXE":            true,
+	"TASK_VERSION":        true,
+	"TASKFILE":            true,
+	"TASKFILE_DIR":        true,
+	"ROOT_TASKFILE":       true,
+	"ROOT_DIR":            true,
+	"USER_WORKING_DIR":    true,
+	"ALIAS":               true,
+	"MATCH":               true,
+	"PATH_LIST_SEPARATOR": true,
+	"FILE_PATH_SEPARATOR": true,
+	"CHECKSUM":            true,
+	"TIMESTAMP":           true,
+	"CHANGED_SOURCES":     true,
+	"ADDED_SOURCES":       true,
+	"REMOVED_SOURCES":     true,
+}
+
+// writeDefinition writes the parts of a compiled task that affect what it
+// produces: its dir, cmds, vars and env. Secrets and volatile vars are left
+// out, and so are vars inherited unchanged from the OS environment.
+func writeDefinition(w io.Writer, t *ast.Task) error {
+	if _, err := fmt.Fprintf(w, "dir\x00%s\x00", relativeDir(t)); err != nil {
+		return err
+	}
+
+	for _, cmd := range t.Cmds {
+		if cmd == nil {
+			continue
+		}
+		// LogCmd is the compiled command with its secrets masked
+		c := cmd.Cmd
+		if cmd.LogCmd != "" {
+			c = cmd.LogCmd
+		}
+		if _, err := fmt.Fprintf(w, "cmd\x00%s\x00%s\x00", c, cmd.Task); err != nil {
+			return err
+		}
+		if err := writeVars(w, "cmd.var", cmd.Vars, false); err != nil {
+			return err
+		}
+	}
+
+	if err := writeVars(w, "var", t.Vars, true); err != nil {
+		return err
+	}
+	return writeVars(w, "env", t.Env, false)
+}
+
+// DefinitionHash returns a hash of the parts of a compiled task that affect
+// what it produces, as written by writeDefinition.
+func DefinitionHash(t *ast.Task) (string, error) {
+	h := xxh3.New()
+	if err := writeDefinition(h, t); err != nil {
+		return "", err
+	}
+	hash := h.Sum128()
+	return fmt.Sprintf("%x%x", hash.Hi, hash.Lo), nil
+}
+
+// relativeDir returns the dir of the task relative to the root Taskfile, so
+// that it doesn't change when the project is moved.
+func relativeDir(t *ast.Task) string {
+	dir := t.Dir
+	if root, ok := t.Vars.Get("ROOT_DIR"); ok {
+		if rootDir, ok := root.Value.(string); ok && rootDir != "" {
+			if rel, err := filepath.Rel(rootDir, t.Dir); err == nil {
+				dir = rel
+			}
+		}
+	}
+	return filepath.ToSlash(dir)
+}
+
+func writeVars(w io.Writer, prefix string, vars *ast.Vars, skipEnviron bool) error {
+	values := varValues(vars, skipEnviron)
+	names := slices.Sorted(maps.Keys(values))
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%s\x00%s\x00%s\x00", prefix, name, values[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// varValues returns the values of the vars that are part of the definition of
+// a task.
+func varValues(vars *ast.Vars, skipEnviron bool) map[string]string {
+	values := make(map[string]string, vars.Len())
+	for name, v := range vars.All() {
+		if v.Secret || volatileVars[name] || strings.HasPrefix(name, "CLI_") {
+			continue
+		}
+		value := fmt.Sprint(v.Value)
+		if skipEnviron {
+			if environ, ok := os.LookupEnv(name); ok && value == environ {
+				continue
+			}
+		}
+		values[name] = value
+	}
+	return values
+}
@@ -2,6 +2,9 @@ package fingerprint
 
 import (
 	"context"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/taskfile/ast"
@@ -13,12 +16,14 @@ type (
 	// A Fingerprinter answers whether a task is up-to-date. It owns the
 	// resolution of the fingerprinting method and the checkers behind it.
 	Fingerprinter struct {
-		defaultMethod  string
+		defaultMethod  ast.Method
+		methods        map[string]*ast.Method
 		tempDir        string
 		dry            bool
 		logger         *logger.Logger
 		statusChecker  StatusCheckable
 		sourcesChecker SourcesCheckable
+		cache          *Cache
 	}
 )
 
@@ -34,9 +39,25 @@ func WithSourcesChecker(checker SourcesCheckable) FingerprinterOption {
 	}
 }
 
+// WithCache shares the glob expansions and file hashes of the given cache
+// between the checkers of the [Fingerprinter].
+func WithCache(cache *Cache) FingerprinterOption {
+	return func(f *Fingerprinter) {
+		f.cache = cache
+	}
+}
+
+// WithMethods makes the methods declared in the Taskfile available to tasks by
+// their name.
+func WithMethods(methods map[string]*ast.Method) FingerprinterOption {
+	return func(f *Fingerprinter) {
+		f.methods = methods
+	}
+}
+
 // NewFingerprinter uses defaultMethod for tasks that don't declare one.
 func NewFingerprinter(
-	defaultMethod string,
+	defaultMethod ast.Method,
 	tempDir string,
 	dry bool,
 	logger *logger.Logger,
@@ -54,23 +75,34 @@ func NewFingerprinter(
 	return f
 }
 
-func (f *Fingerprinter) resolveMethod(t *ast.Task) string {
-	if t.Method != "" {
-		return t.Method
+// resolveMethod returns the method of the task, or the default one. Names
+// declared in the methods of the Taskfile are resolved, unless they shadow a
+// built-in method.
+func (f *Fingerprinter) resolveMethod(t *ast.Task) ast.Method {
+	method := f.defaultMethod
+	if !t.Method.IsZero() {
+		method = t.Method
+	}
+	if method.IsNamed() {
+		name := strings.TrimPrefix(method.Name, ast.NamespaceSeparator)
+		if named, ok := f.methods[name]; ok && named != nil {
+			return *named
+		}
 	}
-	return f.defaultMethod
+	return method
 }
 
 // Kind names the fingerprint variable ("checksum", "timestamp" or "none") the
-// resolved method injects. An invalid method is reported as "checksum" here and
-// rejected by the entry points that build a checker.
+// resolved method injects. The git and custom methods, like an invalid method,
+// are reported as "checksum": they all produce a fingerprint string. An
+// invalid method is rejected by the entry points that build a checker.
 func (f *Fingerprinter) Kind(t *ast.Task) string {
 	if f.sourcesChecker != nil {
 		return f.sourcesChecker.Kind()
 	}
-	switch method := f.resolveMethod(t); method {
-	case "timestamp", "none":
-		return method
+	switch method := f.resolveMethod(t); {
+	case method.Sh == "" && (method.Name == "timestamp" || method.Name == "none"):
+		return method.Name
 	default:
 		return "checksum"
 	}
@@ -78,12 +110,12 @@ func (f *Fingerprinter) Kind(t *ast.Task) string {
 
 // SourceValue returns the value of the fingerprint variable for the given task.
 // It is potentially expensive, so only call it when the task references it.
-func (f *Fingerprinter) SourceValue(t *ast.Task) (any, error) {
+func (f *Fingerprinter) SourceValue(ctx context.Context, t *ast.Task) (any, error) {
 	sourcesChecker, err := f.resolveSourcesChecker(t)
 	if err != nil {
 		return nil, err
 	}
-	return sourcesChecker.Value(t)
+	return sourcesChecker.Value(ctx, t)
 }
 
 // UpToDate considers both the status commands and the sources of a task; one
@@ -102,7 +134,8 @@ func (f *Fingerprinter) UpToDate(ctx context.Context, t *ast.Task) (bool, error)
 	}
 
 	statusIsSet := len(t.Status) != 0
-	sourcesIsSet := len(t.Sources) != 0
+	// A custom method doesn't need sources to produce a fingerprint
+	sourcesIsSet := len(t.Sources) != 0 || f.resolveMethod(t).Sh != ""
 
 	if statusIsSet {
 		statusUpToDate, err = statusChecker.IsUpToDate(ctx, t)
@@ -112,22 +145,114 @@ func (f *Fingerprinter) UpToDate(ctx context.Context, t *ast.Task) (bool, error)
 	}
 
 	if sourcesIsSet {
-		sourcesUpToDate, err = sourcesChecker.IsUpToDate(t)
+		sourcesUpToDate, err = sourcesChecker.IsUpToDate(ctx, t)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	if statusIsSet && sourcesIsSet {
-		return statusUpToDate && sourcesUpToDate, nil
+	var upToDate bool
+	switch {
+	case statusIsSet && sourcesIsSet:
+		upToDate = statusUpToDate && sourcesUpToDate
+	case statusIsSet:
+		upToDate = statusUpToDate
+	case sourcesIsSet:
+		upToDate = sourcesUpToDate
 	}
-	if statusIsSet {
-		return statusUpToDate, nil
+	if upToDate && t.ShouldVerifyGenerates() {
+		return f.generatesUpToDate(t)
 	}
-	if sourcesIsSet {
-		return sourcesUpToDate, nil
+	return upToDate, nil
+}
+
+// generatesUpToDate compares the generated files of the task with the manifest
+// recorded after its last successful run.
+func (f *Fingerprinter) generatesUpToDate(t *ast.Task) (bool, error) {
+	if len(t.Generates) == 0 {
+		return true, nil
 	}
-	return false, nil
+	recorded, err := readManifest(generatesManifestPath(f.tempDir, t))
+	if err != nil {
+		return false, err
+	}
+	if recorded == nil {
+		f.logger.VerboseErrf(logger.Yellow, "task: [%s] no manifest of generated files\n", t.Name())
+		return false, nil
+	}
+	current, err := NewGeneratesManifest(t)
+	if err != nil {
+		return false, err
+	}
+	changed := recorded.Diff(current)
+	for _, path := range changed {
+		f.logger.VerboseErrf(logger.Yellow, "task: [%s] generated file %q changed\n", t.Name(), path)
+	}
+	return len(changed) == 0, nil
+}
+
+// RecordGenerates stores the manifest of the generated files of a task after
+// it ran successfully, if it verifies them.
+func (f *Fingerprinter) RecordGenerates(t *ast.Task) error {
+	if f.dry || !t.ShouldVerifyGenerates() || len(t.Generates) == 0 {
+		return nil
+	}
+	manifest, err := NewGeneratesManifest(t)
+	if err != nil {
+		return err
+	}
+	path, err := saveStatePath(f.tempDir, "generates", t, t.Name())
+	if err != nil {
+		return err
+	}
+	return writeManifest(path, manifest)
+}
+
+// SourceChanges compares the sources of a task with the manifest recorded by
+// [Fingerprinter.RecordSources]. Before the first successful run, all sources
+// are reported as added.
+func (f *Fingerprinter) SourceChanges(t *ast.Task) (*SourceChanges, error) {
+	recorded, err := readManifest(sourcesManifestPath(f.tempDir, t))
+	if err != nil {
+		return nil, err
+	}
+	current, err := f.cache.newSourcesManifest(t)
+	if err != nil {
+		return nil, err
+	}
+	added, modified, removed := recorded.Changes(current)
+	changed := slices.Concat(added, modified)
+	slices.Sort(changed)
+	return &SourceChanges{
+		Changed: changed,
+		Added:   added,
+		Removed: removed,
+	}, nil
+}
+
+// RecordSources stores the manifest of the sources of a task after it ran
+// successfully. The manifest of a failed run is left untouched, so its changes
+// are reported again on the next one. Only tasks that read the manifest, by
+// using the source changes vars or the checksum or git method, record it.
+func (f *Fingerprinter) RecordSources(t *ast.Task) error {
+	if f.dry || len(t.Sources) == 0 {
+		return nil
+	}
+	if !t.UsesSourceChanges {
+		method := f.resolveMethod(t)
+		if method.Sh != "" || (method.Name != "checksum" && method.Name != "git") {
+			return nil
+		}
+	}
+	manifest, err := f.cache.newSourcesManifest(t)
+	if err != nil {
+		return err
+	}
+	path, err := saveStatePath(f.tempDir, "sources", t, t.Name())
+	if err != nil {
+		return err
+	}
+	return writeManifest(path, manifest)
 }
 
 // OnError lets the resolved sources checker clean up after a failed run.
@@ -136,6 +261,11 @@ func (f *Fingerprinter) OnError(t *ast.Task) error {
 	if err != nil {
 		return err
 	}
+	if t.ShouldVerifyGenerates() {
+		if err := os.Remove(generatesManifestPath(f.tempDir, t)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	return sourcesChecker.OnError(t)
 }
 
@@ -144,5 +274,9 @@ func (f *Fingerprinter) resolveSourcesChecker(t *ast.Task) (SourcesCheckable, er
 	if f.sourcesChecker != nil {
 		return f.sourcesChecker, nil
 	}
-	return NewSourcesChecker(f.resolveMethod(t), f.tempDir, f.dry)
+	method := f.resolveMethod(t)
+	if method.Sh != "" {
+		return NewCustomChecker(method.Sh, f.tempDir, f.dry, f.cache), nil
+	}
+	return NewSourcesChecker(method.Name, f.tempDir, f.dry, f.cache)
 }
@@ -54,7 +54,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 			},
 			setupMockStatusChecker: nil,
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: true,
 		},
@@ -66,7 +66,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 			},
 			setupMockStatusChecker: nil,
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			expected: false,
 		},
@@ -92,7 +92,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: true,
 		},
@@ -106,7 +106,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			expected: false,
 		},
@@ -132,7 +132,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(true, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(true, nil)
 			},
 			expected: false,
 		},
@@ -146,7 +146,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			setupMockSourcesChecker: func(m *MockSourcesCheckable) {
-				m.EXPECT().IsUpToDate(mock.Anything).Return(false, nil)
+				m.EXPECT().IsUpToDate(mock.Anything, mock.Anything).Return(false, nil)
 			},
 			expected: false,
 		},
@@ -165,7 +165,7 @@ func TestFingerprinterUpToDate(t *testing.T) {
 				tt.setupMockSourcesChecker(mockSourcesChecker)
 			}
 
-			f := NewFingerprinter("checksum", "", false, nil,
+			f := NewFingerprinter(ast.Method{Name: "checksum"}, "", false, nil,
 				WithStatusChecker(mockStatusChecker),
 				WithSourcesChecker(mockSourcesChecker),
 			)
@@ -222,16 +222,16 @@ func TestFingerprinterMethodResolution(t *testing.T) {
 			require.NoError(t, os.WriteFile(filepath.Join(dir, "source.txt"), []byte("content"), 0o644))
 			task := &ast.Task{
 				Dir:     dir,
-				Method:  tt.method,
+				Method:  ast.Method{Name: tt.method},
 				Sources: []*ast.Glob{{Glob: "source.txt"}},
 			}
 
-			f := NewFingerprinter(tt.defaultMethod, t.TempDir(), true, nil)
+			f := NewFingerprinter(ast.Method{Name: tt.defaultMethod}, t.TempDir(), true, nil)
 
 			assert.Equal(t, tt.expectedKind, f.Kind(task))
 
 			// A timestamp checker yields a time, the other two a string.
-			value, err := f.SourceValue(task)
+			value, err := f.SourceValue(t.Context(), task)
 			require.NoError(t, err)
 			assert.IsType(t, tt.expectedValue, value)
 		})
@@ -245,11 +245,11 @@ func TestFingerprinterInvalidMethod(t *testing.T) {
 
 	const wantErr = `task: invalid method "Checksum"`
 	task := &ast.Task{Sources: []*ast.Glob{{Glob: "source.txt"}}}
-	f := NewFingerprinter("Checksum", t.TempDir(), true, nil)
+	f := NewFingerprinter(ast.Method{Name: "Checksum"}, t.TempDir(), true, nil)
 
 	assert.Equal(t, "checksum", f.Kind(task))
 
-	_, err := f.SourceValue(task)
+	_, err := f.SourceValue(t.Context(), task)
 	require.ErrorIs(t, err, ErrInvalidMethod)
 	require.EqualError(t, err, wantErr)
 	_, err = f.UpToDate(t.Context(), task)
@@ -0,0 +1,27 @@
+package fingerprint
+
+import (
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// NewGeneratesManifest hashes the files currently matched by the generates of
+// the given task.
+func NewGeneratesManifest(t *ast.Task) (Manifest, error) {
+	files, err := Globs(t.Dir, t.Generates, t.ShouldUseGitignore())
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(files))
+	buf := make([]byte, 128*1024)
+	for i, f := range files {
+		if hashes[i], err = hashFile(f, buf); err != nil {
+			return nil, err
+		}
+	}
+	return newManifest(t.Dir, files, hashes), nil
+}
+
+func generatesManifestPath(tempDir string, t *ast.Task) string {
+	return statePath(tempDir, "generates", t, t.Name())
+}
@@ -5,16 +5,20 @@ import (
 	"path/filepath"
 	"slices"
 
-	"github.com/go-task/task/v3/internal/execext"
-	"github.com/go-task/task/v3/internal/filepathext"
-	"github.com/go-task/task/v3/internal/fsext"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
 func Globs(dir string, globs []*ast.Glob, useGitignore bool) ([]string, error) {
+	var c *Cache
+	return c.Globs(dir, globs, useGitignore)
+}
+
+// Globs is like the package level [Globs], but reuses the expansions memoized
+// by the cache.
+func (c *Cache) Globs(dir string, globs []*ast.Glob, useGitignore bool) ([]string, error) {
 	resultMap := make(map[string]bool)
 	for _, g := range globs {
-		matches, err := glob(dir, g.Glob)
+		matches, err := c.glob(dir, g.Glob)
 		if err != nil {
 			continue
 		}
@@ -30,33 +34,6 @@ func Globs(dir string, globs []*ast.Glob, useGitignore bool) ([]string, error) {
 	return collectKeys(resultMap), nil
 }
 
-func glob(dir string, g string) ([]string, error) {
-	g = filepathext.SmartJoin(dir, g)
-
-	if results, ok, err := fsext.FastRecursiveGlob(g); ok {
-		return results, err
-	}
-
-	fs, err := execext.ExpandFields(g)
-	if err != nil {
-		return nil, err
-	}
-
-	results := make(map[string]bool, len(fs))
-
-	for _, f := range fs {
-		info, err := os.Stat(f)
-		if err != nil {
-			return nil, err
-		}
-		if info.IsDir() {
-			continue
-		}
-		results[f] = true
-	}
-	return collectKeys(results), nil
-}
-
 func collectKeys(m map[string]bool) []string {
 	keys := make([]string, 0, len(m))
 	for k, v := range m {
@@ -68,3 +45,25 @@ func collectKeys(m map[string]bool) []string {
 	slices.Sort(keys)
 	return keys
 }
+
+// generatesExist reports whether every glob in the generates of the task
+// matches at least one file.
+func (c *Cache) generatesExist(t *ast.Task) (bool, error) {
+	for _, g := range t.Generates {
+		// Exclusion patterns don't represent output files; skip them.
+		if g.Negate {
+			continue
+		}
+		files, err := c.glob(t.Dir, g.Glob)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if len(files) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
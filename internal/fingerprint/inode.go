@@ -0,0 +1,15 @@
+//go:build !windows
+
+package fingerprint
+
+import (
+	"os"
+	"syscall"
+)
+
+func inode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino)
+	}
+	return 0
+}
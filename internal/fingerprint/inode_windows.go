@@ -0,0 +1,11 @@
+//go:build windows
+
+package fingerprint
+
+import "os"
+
+// NOTE: This always returns 0 since os.FileInfo doesn't expose the file index
+// on Windows. Size and mtime are still compared.
+func inode(info os.FileInfo) uint64 {
+	return 0
+}
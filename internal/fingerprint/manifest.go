@@ -0,0 +1,99 @@
+package fingerprint
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// A Manifest maps files, relative to the dir of a task, to the hash of their
+// contents.
+type Manifest map[string]string
+
+func newManifest(dir string, files []string, hashes []string) Manifest {
+	manifest := make(Manifest, len(files))
+	for i, f := range files {
+		rel, err := filepath.Rel(dir, filepath.FromSlash(f))
+		if err != nil {
+			rel = f
+		}
+		manifest[filepath.ToSlash(rel)] = hashes[i]
+	}
+	return manifest
+}
+
+// Diff returns the sorted paths that were added, removed or changed in other.
+func (m Manifest) Diff(other Manifest) []string {
+	added, modified, removed := m.Changes(other)
+	changed := slices.Concat(added, modified, removed)
+	slices.Sort(changed)
+	return changed
+}
+
+// Changes returns the sorted paths that were added, modified and removed in
+// other.
+func (m Manifest) Changes(other Manifest) (added, modified, removed []string) {
+	for path, h := range other {
+		old, ok := m[path]
+		switch {
+		case !ok:
+			added = append(added, path)
+		case old != h:
+			modified = append(modified, path)
+		}
+	}
+	for path := range m {
+		if _, ok := other[path]; !ok {
+			removed = append(removed, path)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(modified)
+	slices.Sort(removed)
+	return added, modified, removed
+}
+
+// readManifest reads the manifest at the given path. It returns nil if there
+// is none.
+func readManifest(path string) (Manifest, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	manifest := Manifest{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		h, path, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		manifest[path] = h
+	}
+	return manifest, scanner.Err()
+}
+
+func writeManifest(path string, manifest Manifest) error {
+	paths := make([]string, 0, len(manifest))
+	for path := range manifest {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var sb strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&sb, "%s %s\n", manifest[path], path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
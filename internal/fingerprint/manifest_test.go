@@ -0,0 +1,49 @@
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestDiff(t *testing.T) {
+	t.Parallel()
+
+	recorded := Manifest{
+		"a.txt": "1",
+		"b.txt": "2",
+		"c.txt": "3",
+	}
+	current := Manifest{
+		"a.txt": "1",
+		"b.txt": "changed",
+		"d.txt": "4",
+	}
+	assert.Equal(t, []string{"b.txt", "c.txt", "d.txt"}, recorded.Diff(current))
+	assert.Empty(t, recorded.Diff(recorded))
+}
+
+func TestManifestChanges(t *testing.T) {
+	t.Parallel()
+
+	recorded := Manifest{
+		"a.txt": "1",
+		"b.txt": "2",
+		"c.txt": "3",
+	}
+	current := Manifest{
+		"a.txt": "1",
+		"b.txt": "changed",
+		"d.txt": "4",
+	}
+	added, modified, removed := recorded.Changes(current)
+	assert.Equal(t, []string{"d.txt"}, added)
+	assert.Equal(t, []string{"b.txt"}, modified)
+	assert.Equal(t, []string{"c.txt"}, removed)
+
+	// Without a recorded manifest, everything is added
+	added, modified, removed = Manifest(nil).Changes(current)
+	assert.Equal(t, []string{"a.txt", "b.txt", "d.txt"}, added)
+	assert.Empty(t, modified)
+	assert.Empty(t, removed)
+}
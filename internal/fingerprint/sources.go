@@ -10,12 +10,14 @@ import (
 // method name apart from a checker failing on the sources themselves.
 var ErrInvalidMethod = errors.New("invalid method")
 
-func NewSourcesChecker(method, tempDir string, dry bool) (SourcesCheckable, error) {
+func NewSourcesChecker(method, tempDir string, dry bool, cache *Cache) (SourcesCheckable, error) {
 	switch method {
 	case "timestamp":
-		return NewTimestampChecker(tempDir, dry), nil
+		return NewTimestampChecker(tempDir, dry, cache), nil
 	case "checksum":
-		return NewChecksumChecker(tempDir, dry), nil
+		return NewChecksumChecker(tempDir, dry, cache), nil
+	case "git":
+		return NewGitChecker(tempDir, dry, cache), nil
 	case "none":
 		return NoneChecker{}, nil
 	default:
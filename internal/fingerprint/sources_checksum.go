@@ -1,8 +1,8 @@
 package fingerprint
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -10,7 +10,6 @@ import (
 
 	"github.com/zeebo/xxh3"
 
-	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -19,16 +18,20 @@ import (
 type ChecksumChecker struct {
 	tempDir string
 	dry     bool
+	cache   *Cache
 }
 
-func NewChecksumChecker(tempDir string, dry bool) *ChecksumChecker {
+// NewChecksumChecker creates a checker that reuses the work memoized by cache,
+// which may be nil.
+func NewChecksumChecker(tempDir string, dry bool, cache *Cache) *ChecksumChecker {
 	return &ChecksumChecker{
 		tempDir: tempDir,
 		dry:     dry,
+		cache:   cache,
 	}
 }
 
-func (checker *ChecksumChecker) IsUpToDate(t *ast.Task) (bool, error) {
+func (checker *ChecksumChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
 	if len(t.Sources) == 0 {
 		return false, nil
 	}
@@ -44,36 +47,20 @@ func (checker *ChecksumChecker) IsUpToDate(t *ast.Task) (bool, error) {
 	}
 
 	if !checker.dry && oldHash != newHash {
-		_ = os.MkdirAll(filepathext.SmartJoin(checker.tempDir, "checksum"), 0o755)
-		if err = os.WriteFile(checksumFile, []byte(newHash+"\n"), 0o644); err != nil {
+		if err = writeState(checker.tempDir, "checksum", t, t.Name(), newHash); err != nil {
 			return false, err
 		}
 	}
 
-	if len(t.Generates) > 0 {
-		// For each specified 'generates' field, check whether the files actually exist
-		for _, g := range t.Generates {
-			// Exclusion patterns don't represent output files; skip them.
-			if g.Negate {
-				continue
-			}
-			generates, err := glob(t.Dir, g.Glob)
-			if os.IsNotExist(err) {
-				return false, nil
-			}
-			if err != nil {
-				return false, err
-			}
-			if len(generates) == 0 {
-				return false, nil
-			}
-		}
+	exist, err := checker.cache.generatesExist(t)
+	if err != nil || !exist {
+		return false, err
 	}
 
 	return oldHash == newHash, nil
 }
 
-func (checker *ChecksumChecker) Value(t *ast.Task) (any, error) {
+func (checker *ChecksumChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
 	return checker.checksum(t)
 }
 
@@ -88,35 +75,28 @@ func (*ChecksumChecker) Kind() string {
 	return "checksum"
 }
 
-// readerOnly hides any WriterTo/ReaderFrom implementation of the wrapped
-// reader, forcing io.CopyBuffer to use the caller-provided buffer.
-type readerOnly struct{ io.Reader }
-
 func (c *ChecksumChecker) checksum(t *ast.Task) (string, error) {
-	sources, err := Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+	sources, err := c.cache.Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+	if err != nil {
+		return "", err
+	}
+	hashes, err := c.cache.hashFiles(sources)
 	if err != nil {
 		return "", err
 	}
 
 	h := xxh3.New()
-	buf := make([]byte, 128*1024)
-	for _, f := range sources {
+	for i, f := range sources {
 		// also sum the filename, so checksum changes for renaming a file
-		if _, err := io.CopyBuffer(h, strings.NewReader(filepath.Base(f)), buf); err != nil {
+		if _, err := fmt.Fprintf(h, "%s\x00%s\x00", filepath.Base(f), hashes[i]); err != nil {
 			return "", err
 		}
-		file, err := os.Open(f)
-		if err != nil {
-			return "", err
-		}
-		// Wrap the file in a plain io.Reader so io.CopyBuffer cannot take the
-		// (*os.File).WriteTo fast path, which ignores buf and allocates a fresh
-		// 32KiB buffer for every file. Reusing buf keeps this loop allocation-free.
-		if _, err = io.CopyBuffer(h, readerOnly{file}, buf); err != nil {
-			file.Close()
+	}
+
+	if t.ShouldFingerprintTask() {
+		if err := writeDefinition(h, t); err != nil {
 			return "", err
 		}
-		file.Close()
 	}
 
 	hash := h.Sum128()
@@ -124,7 +104,7 @@ func (c *ChecksumChecker) checksum(t *ast.Task) (string, error) {
 }
 
 func (checker *ChecksumChecker) checksumFilePath(t *ast.Task) string {
-	return filepath.Join(checker.tempDir, "checksum", normalizeFilename(t.Name()))
+	return statePath(checker.tempDir, "checksum", t, t.Name())
 }
 
 var checksumFilenameRegexp = regexp.MustCompile("[^[:alnum:]]")
@@ -0,0 +1,142 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/go-task/task/v3/internal/env"
+	"github.com/go-task/task/v3/internal/execext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// CustomChecker checks if a task is up-to-date by running a command that
+// prints a fingerprint, like the digest of a Docker image or the version of a
+// database schema. The fingerprint is stored and compared like a checksum.
+type CustomChecker struct {
+	sh      string
+	tempDir string
+	dry     bool
+	cache   *Cache
+}
+
+// customCheckerInput is written as JSON to the stdin of the command of a
+// [CustomChecker].
+type customCheckerInput struct {
+	Task    string   `json:"task"`
+	Dir     string   `json:"dir"`
+	Sources []string `json:"sources"`
+}
+
+// NewCustomChecker creates a checker running sh, which reuses the work memoized
+// by cache, which may be nil.
+func NewCustomChecker(sh, tempDir string, dry bool, cache *Cache) *CustomChecker {
+	return &CustomChecker{
+		sh:      sh,
+		tempDir: tempDir,
+		dry:     dry,
+		cache:   cache,
+	}
+}
+
+func (checker *CustomChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
+	stateFile := checker.stateFilePath(t)
+
+	data, _ := os.ReadFile(stateFile)
+	oldFingerprint := strings.TrimSpace(string(data))
+
+	newFingerprint, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return false, err
+	}
+
+	if !checker.dry && oldFingerprint != newFingerprint {
+		if err = writeState(checker.tempDir, "custom", t, t.Name(), newFingerprint); err != nil {
+			return false, err
+		}
+	}
+
+	exist, err := checker.cache.generatesExist(t)
+	if err != nil || !exist {
+		return false, err
+	}
+
+	return oldFingerprint == newFingerprint, nil
+}
+
+func (checker *CustomChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	return checker.fingerprint(ctx, t)
+}
+
+func (checker *CustomChecker) OnError(t *ast.Task) error {
+	err := os.Remove(checker.stateFilePath(t))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (*CustomChecker) Kind() string {
+	return "checksum"
+}
+
+func (checker *CustomChecker) fingerprint(ctx context.Context, t *ast.Task) (string, error) {
+	sources, err := checker.cache.Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+	if err != nil {
+		return "", err
+	}
+	input := customCheckerInput{
+		Task:    t.Name(),
+		Dir:     t.Dir,
+		Sources: make([]string, 0, len(sources)),
+	}
+	for _, source := range sources {
+		rel, err := filepath.Rel(t.Dir, source)
+		if err != nil {
+			return "", err
+		}
+		input.Sources = append(input.Sources, filepath.ToSlash(rel))
+	}
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = execext.RunCommand(ctx, &execext.RunCommandOptions{
+		Command: checker.sh,
+		Dir:     t.Dir,
+		Env:     env.Get(t),
+		Stdin:   bytes.NewReader(stdin),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	if err != nil {
+		return "", fmt.Errorf("task: [%s] method command failed: %w: %s", t.Name(), err, strings.TrimSpace(stderr.String()))
+	}
+
+	fingerprint := strings.TrimSpace(stdout.String())
+	if fingerprint == "" {
+		return "", fmt.Errorf("task: [%s] method command printed no fingerprint", t.Name())
+	}
+	if t.ShouldFingerprintTask() {
+		h := xxh3.New()
+		fmt.Fprintf(h, "%s\x00", fingerprint)
+		if err := writeDefinition(h, t); err != nil {
+			return "", err
+		}
+		hash := h.Sum128()
+		fingerprint = fmt.Sprintf("%x%x", hash.Hi, hash.Lo)
+	}
+	return fingerprint, nil
+}
+
+func (checker *CustomChecker) stateFilePath(t *ast.Task) string {
+	return statePath(checker.tempDir, "custom", t, t.Name())
+}
@@ -0,0 +1,242 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// GitChecker checks if the sources of a task changed using the git object IDs
+// of the files matching them. Tracked files take their ID from the index, and
+// only files that are modified or untracked are hashed, by git itself. Files
+// ignored by git are never part of the sources. Outside of a git repository,
+// the fingerprint is the one of [ChecksumChecker].
+type GitChecker struct {
+	tempDir string
+	dry     bool
+	cache   *Cache
+}
+
+// NewGitChecker creates a checker that reuses the work memoized by cache, which
+// may be nil.
+func NewGitChecker(tempDir string, dry bool, cache *Cache) *GitChecker {
+	return &GitChecker{
+		tempDir: tempDir,
+		dry:     dry,
+		cache:   cache,
+	}
+}
+
+func (checker *GitChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
+	if len(t.Sources) == 0 {
+		return false, nil
+	}
+
+	stateFile := checker.stateFilePath(t)
+
+	data, _ := os.ReadFile(stateFile)
+	oldHash := strings.TrimSpace(string(data))
+
+	newHash, err := checker.fingerprint(ctx, t)
+	if err != nil {
+		return false, err
+	}
+
+	if !checker.dry && oldHash != newHash {
+		if err = writeState(checker.tempDir, "git", t, t.Name(), newHash); err != nil {
+			return false, err
+		}
+	}
+
+	exist, err := checker.cache.generatesExist(t)
+	if err != nil || !exist {
+		return false, err
+	}
+
+	return oldHash == newHash, nil
+}
+
+func (checker *GitChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	return checker.fingerprint(ctx, t)
+}
+
+func (checker *GitChecker) OnError(t *ast.Task) error {
+	if len(t.Sources) == 0 {
+		return nil
+	}
+	return os.Remove(checker.stateFilePath(t))
+}
+
+func (*GitChecker) Kind() string {
+	return "git"
+}
+
+func (checker *GitChecker) fingerprint(ctx context.Context, t *ast.Task) (string, error) {
+	out, err := git(ctx, t.Dir, nil, "rev-parse", "--show-toplevel", "--show-prefix")
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		// Outside of a git repository, the content of the sources is used
+		value, err := NewChecksumChecker(checker.tempDir, true, checker.cache).Value(ctx, t)
+		if err != nil {
+			return "", err
+		}
+		return value.(string), nil
+	}
+	repoDir, prefix, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	repoDir = filepath.FromSlash(repoDir)
+
+	// The sources are matched like by the other methods, and their paths are
+	// made relative to the root of the repository. Those outside of it start
+	// with "../".
+	files, err := checker.cache.Globs(t.Dir, t.Sources, false)
+	if err != nil {
+		return "", err
+	}
+	sources := make(map[string]string, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(t.Dir, filepath.FromSlash(file))
+		if err != nil {
+			return "", err
+		}
+		sources[path.Join(prefix, filepath.ToSlash(rel))] = file
+	}
+
+	objects, err := gitObjects(ctx, repoDir, sources)
+	if err != nil {
+		return "", err
+	}
+
+	h := xxh3.New()
+	for _, name := range slices.Sorted(maps.Keys(objects)) {
+		fmt.Fprintf(h, "%s\x00%s\x00", name, objects[name])
+	}
+
+	if t.ShouldFingerprintTask() {
+		if err := writeDefinition(h, t); err != nil {
+			return "", err
+		}
+	}
+
+	hash := h.Sum128()
+	return fmt.Sprintf("%x%x", hash.Hi, hash.Lo), nil
+}
+
+// gitObjects returns the git object IDs of the sources, which map the paths
+// relative to the root of the repository to the files. Clean files take their
+// ID from the index, and the modified or untracked ones, like the files outside
+// of the repository, are hashed by git. Files ignored by git are left out.
+func gitObjects(ctx context.Context, repoDir string, sources map[string]string) (map[string]string, error) {
+	objects := make(map[string]string, len(sources))
+	var hashed []string
+	var inRepo []string
+	for name := range sources {
+		if strings.HasPrefix(name, "../") {
+			hashed = append(hashed, name)
+		} else {
+			inRepo = append(inRepo, name)
+		}
+	}
+
+	if len(inRepo) > 0 {
+		pathspec := []string{"--"}
+		if dir := commonDir(inRepo); dir != "." {
+			pathspec = append(pathspec, ":(literal)"+dir)
+		}
+
+		// The object IDs of the files, as they are in the index
+		index, err := git(ctx, repoDir, nil, append([]string{"ls-files", "--stage", "-z"}, pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		for entry := range strings.SplitSeq(string(index), "\x00") {
+			// <mode> SP <object> SP <stage> TAB <path>
+			info, name, ok := strings.Cut(entry, "\t")
+			if fields := strings.Fields(info); ok && len(fields) == 3 && sources[name] != "" {
+				objects[name] = fields[1]
+			}
+		}
+
+		// The files that differ from the index, or that are not tracked
+		status, err := git(ctx, repoDir, nil, append([]string{"status", "--porcelain", "-z", "--untracked-files=all"}, pathspec...)...)
+		if err != nil {
+			return nil, err
+		}
+		entries := strings.Split(string(status), "\x00")
+		for i := 0; i < len(entries); i++ {
+			// <XY> SP <path>, followed by the original path for renames and copies
+			entry := entries[i]
+			if len(entry) < 4 {
+				continue
+			}
+			if entry[0] == 'R' || entry[0] == 'C' {
+				i++
+			}
+			if name := entry[3:]; sources[name] != "" {
+				hashed = append(hashed, name)
+			}
+		}
+	}
+
+	if len(hashed) > 0 {
+		slices.Sort(hashed)
+		hashed = slices.Compact(hashed)
+		files := make([]string, len(hashed))
+		for i, name := range hashed {
+			files[i] = filepath.FromSlash(sources[name])
+		}
+		stdin := strings.NewReader(strings.Join(files, "\n") + "\n")
+		out, err := git(ctx, repoDir, stdin, "hash-object", "--stdin-paths")
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range strings.Fields(string(out)) {
+			if i < len(hashed) {
+				objects[hashed[i]] = id
+			}
+		}
+	}
+	return objects, nil
+}
+
+// commonDir returns the deepest directory containing all the paths.
+func commonDir(paths []string) string {
+	dir := path.Dir(paths[0])
+	for _, p := range paths[1:] {
+		for dir != "." && !strings.HasPrefix(p, dir+"/") {
+			dir = path.Dir(dir)
+		}
+	}
+	return dir
+}
+
+func (checker *GitChecker) stateFilePath(t *ast.Task) string {
+	return statePath(checker.tempDir, "git", t, t.Name())
+}
+
+// git runs a git command in dir and returns its output.
+func git(ctx context.Context, dir string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("task: git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package fingerprint
+
+import (
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// SourceChanges lists the sources of a task, relative to its dir, that changed
+// since its last successful run.
+type SourceChanges struct {
+	// Changed holds the sources that were added or modified.
+	Changed []string
+	Added   []string
+	Removed []string
+}
+
+func (c *Cache) newSourcesManifest(t *ast.Task) (Manifest, error) {
+	files, err := c.Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := c.hashFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	return newManifest(t.Dir, files, hashes), nil
+}
+
+func sourcesManifestPath(tempDir string, t *ast.Task) string {
+	return statePath(tempDir, "sources", t, t.Name())
+}
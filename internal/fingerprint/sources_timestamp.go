@@ -1,8 +1,8 @@
 package fingerprint
 
 import (
+	"context"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/go-task/task/v3/taskfile/ast"
@@ -13,53 +13,47 @@ import (
 type TimestampChecker struct {
 	tempDir string
 	dry     bool
+	cache   *Cache
 }
 
-func NewTimestampChecker(tempDir string, dry bool) *TimestampChecker {
+// NewTimestampChecker creates a checker that reuses the glob expansions
+// memoized by cache, which may be nil.
+func NewTimestampChecker(tempDir string, dry bool, cache *Cache) *TimestampChecker {
 	return &TimestampChecker{
 		tempDir: tempDir,
 		dry:     dry,
+		cache:   cache,
 	}
 }
 
 // IsUpToDate implements the Checker interface
-func (checker *TimestampChecker) IsUpToDate(t *ast.Task) (bool, error) {
+func (checker *TimestampChecker) IsUpToDate(ctx context.Context, t *ast.Task) (bool, error) {
 	if len(t.Sources) == 0 {
 		return false, nil
 	}
 
-	sources, err := Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+	sources, err := checker.cache.Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
 	if err != nil {
 		return false, nil
 	}
 
 	// If generates are declared, ensure they all exist. A missing generated
 	// file means the task must run regardless of timestamps.
-	if len(t.Generates) > 0 {
-		for _, g := range t.Generates {
-			// Exclusion patterns don't represent output files; skip them.
-			if g.Negate {
-				continue
-			}
-			files, err := glob(t.Dir, g.Glob)
-			if os.IsNotExist(err) {
-				return false, nil
-			}
-			if err != nil {
-				return false, err
-			}
-			if len(files) == 0 {
-				return false, nil
-			}
-		}
+	if exist, err := checker.cache.generatesExist(t); err != nil || !exist {
+		return false, err
 	}
 
-	generates, err := Globs(t.Dir, t.Generates, t.ShouldUseGitignore())
+	generates, err := checker.cache.Globs(t.Dir, t.Generates, t.ShouldUseGitignore())
 	if err != nil {
 		return false, nil
 	}
 
 	timestampFile := checker.timestampFilePath(t)
+	if !checker.dry {
+		if timestampFile, err = saveStatePath(checker.tempDir, "timestamp", t, t.Task); err != nil {
+			return false, err
+		}
+	}
 
 	// If the file exists, add the file path to the generates.
 	// If the generate file is old, the task will be executed.
@@ -69,9 +63,6 @@ func (checker *TimestampChecker) IsUpToDate(t *ast.Task) (bool, error) {
 	} else {
 		// Create the timestamp file for the next execution when the file does not exist.
 		if !checker.dry {
-			if err := os.MkdirAll(filepath.Dir(timestampFile), 0o755); err != nil {
-				return false, err
-			}
 			f, err := os.Create(timestampFile)
 			if err != nil {
 				return false, err
@@ -111,8 +102,8 @@ func (checker *TimestampChecker) Kind() string {
 }
 
 // Value implements the Checker Interface
-func (checker *TimestampChecker) Value(t *ast.Task) (any, error) {
-	sources, err := Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
+func (checker *TimestampChecker) Value(ctx context.Context, t *ast.Task) (any, error) {
+	sources, err := checker.cache.Globs(t.Dir, t.Sources, t.ShouldUseGitignore())
 	if err != nil {
 		return time.Now(), err
 	}
@@ -169,5 +160,5 @@ func (*TimestampChecker) OnError(t *ast.Task) error {
 }
 
 func (checker *TimestampChecker) timestampFilePath(t *ast.Task) string {
-	return filepath.Join(checker.tempDir, "timestamp", normalizeFilename(t.Task))
+	return statePath(checker.tempDir, "timestamp", t, t.Task)
 }
@@ -0,0 +1,289 @@
+package fingerprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zeebo/xxh3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// stateKinds are the kinds of states kept per call variant of a task, each in
+// its own directory.
+var stateKinds = []string{"checksum", "timestamp", "generates", "sources", "git", "custom"}
+
+// stateKeyRegexp matches the keys returned by stateKey.
+var stateKeyRegexp = regexp.MustCompile("^(.*)-[0-9a-f]{16}$")
+
+// stateIndexMutex serializes the updates of the state index by the tasks of a
+// run.
+var stateIndexMutex sync.Mutex
+
+// A StateIndexEntry describes the call variant of a task that a state file
+// belongs to. The index is only read to list and prune the states.
+type StateIndexEntry struct {
+	Task string            `json:"task"`
+	Dir  string            `json:"dir"`
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// stateKey identifies a call variant of a task: the same task called with
+// other vars, in another dir or through another wildcard match gets its own
+// state. Only the vars given to the call are part of the key, so the vars of
+// the Taskfile, like dynamic ones, don't make a new variant on every run. The
+// key starts with the task name to keep it recognizable.
+func stateKey(t *ast.Task) string {
+	return newStateIndexEntry(t).key()
+}
+
+func (entry StateIndexEntry) key() string {
+	h := xxh3.New()
+	fmt.Fprintf(h, "task\x00%s\x00dir\x00%s\x00", entry.Task, entry.Dir)
+	for _, name := range slices.Sorted(maps.Keys(entry.Vars)) {
+		fmt.Fprintf(h, "var\x00%s\x00%s\x00", name, entry.Vars[name])
+	}
+	return fmt.Sprintf("%s-%016x", normalizeFilename(entry.Task), h.Sum64())
+}
+
+func newStateIndexEntry(t *ast.Task) StateIndexEntry {
+	name := t.FullName
+	if name == "" {
+		name = t.Task
+	}
+	entry := StateIndexEntry{
+		Task: name,
+		Dir:  relativeDir(t),
+	}
+	for name, value := range varValues(t.CallVars, false) {
+		if entry.Vars == nil {
+			entry.Vars = map[string]string{}
+		}
+		entry.Vars[name] = value
+	}
+	return entry
+}
+
+// statePath returns the path of the state of the given kind, like "checksum",
+// for the call variant of t. If that state doesn't exist yet, a state file
+// from before states were kept per call variant, found at legacyName, is
+// returned instead. It has no side effects, so it is safe to call while
+// compiling a task or in dry mode.
+func statePath(tempDir, kind string, t *ast.Task, legacyName string) string {
+	path := filepath.Join(tempDir, kind, stateKey(t))
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	legacyPath := filepath.Join(tempDir, kind, normalizeFilename(legacyName))
+	if info, err := os.Stat(legacyPath); err == nil && info.Mode().IsRegular() {
+		return legacyPath
+	}
+	return path
+}
+
+// saveStatePath returns the path to save the state of the given kind for the
+// call variant of t to. A legacy state file is moved there, its directory is
+// created and the call variant is recorded in the index at tempDir/index.json.
+func saveStatePath(tempDir, kind string, t *ast.Task, legacyName string) (string, error) {
+	key := stateKey(t)
+	path := filepath.Join(tempDir, kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		legacyPath := filepath.Join(tempDir, kind, normalizeFilename(legacyName))
+		if info, err := os.Stat(legacyPath); err == nil && info.Mode().IsRegular() {
+			if err := os.Rename(legacyPath, path); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := addToStateIndex(tempDir, key, newStateIndexEntry(t)); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// writeState saves value as the state of the given kind for the call variant
+// of t.
+func writeState(tempDir, kind string, t *ast.Task, legacyName, value string) error {
+	path, err := saveStatePath(tempDir, kind, t, legacyName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(value+"\n"), 0o644)
+}
+
+func addToStateIndex(tempDir, key string, entry StateIndexEntry) error {
+	stateIndexMutex.Lock()
+	defer stateIndexMutex.Unlock()
+
+	index := readStateIndex(tempDir)
+	if current, ok := index[key]; ok && reflect.DeepEqual(current, entry) {
+		return nil
+	}
+	index[key] = entry
+	return writeStateIndex(tempDir, index)
+}
+
+func writeStateIndex(tempDir string, index map[string]StateIndexEntry) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tempDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(tempDir, "index.json"), append(data, '\n'), 0o644)
+}
+
+func readStateIndex(tempDir string) map[string]StateIndexEntry {
+	index := map[string]StateIndexEntry{}
+	if data, err := os.ReadFile(filepath.Join(tempDir, "index.json")); err == nil {
+		_ = json.Unmarshal(data, &index)
+	}
+	return index
+}
+
+// A State is a file recording the fingerprint of a call variant of a task.
+type State struct {
+	// Kind is the kind of the state, like "checksum" or "timestamp".
+	Kind string
+	// Key identifies the call variant of the task.
+	Key string
+	// Path is the path of the state file.
+	Path string
+	// Task is the name of the task, as recorded in the index. It is empty for
+	// the states missing from the index, like the ones written by older
+	// versions of Task.
+	Task string
+	// Stale is true for the states keyed per call variant that Task can no
+	// longer look up: the ones missing from the index, or whose key doesn't
+	// match their call variant anymore, like the ones keyed by an older
+	// version of Task.
+	Stale bool
+}
+
+// ReadStates lists the states stored in tempDir, sorted by kind and key.
+func ReadStates(tempDir string) ([]*State, error) {
+	index := readStateIndex(tempDir)
+	var states []*State
+	for _, kind := range stateKinds {
+		entries, err := os.ReadDir(filepath.Join(tempDir, kind))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			indexEntry, indexed := index[entry.Name()]
+			states = append(states, &State{
+				Kind:  kind,
+				Key:   entry.Name(),
+				Path:  filepath.Join(tempDir, kind, entry.Name()),
+				Task:  indexEntry.Task,
+				Stale: stateKeyRegexp.MatchString(entry.Name()) && (!indexed || indexEntry.key() != entry.Name()),
+			})
+		}
+	}
+	return states, nil
+}
+
+// RemoveStates deletes the given states from tempDir, along with their entries
+// in the index.
+func RemoveStates(tempDir string, states []*State) error {
+	for _, state := range states {
+		if err := os.Remove(state.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	stateIndexMutex.Lock()
+	defer stateIndexMutex.Unlock()
+
+	path := filepath.Join(tempDir, "index.json")
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	index := readStateIndex(tempDir)
+	for _, state := range states {
+		delete(index, state.Key)
+	}
+	return writeStateIndex(tempDir, index)
+}
+
+// PruneStateIndex removes the entries of the index at tempDir/index.json that
+// no state file belongs to anymore, and returns their keys. With dry, the
+// index is left untouched.
+func PruneStateIndex(tempDir string, dry bool) ([]string, error) {
+	stateIndexMutex.Lock()
+	defer stateIndexMutex.Unlock()
+
+	index := readStateIndex(tempDir)
+	var keys []string
+	for key := range index {
+		if !slices.ContainsFunc(stateKinds, func(kind string) bool {
+			_, err := os.Stat(filepath.Join(tempDir, kind, key))
+			return err == nil
+		}) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	if dry || len(keys) == 0 {
+		return keys, nil
+	}
+	for _, key := range keys {
+		delete(index, key)
+	}
+	return keys, writeStateIndex(tempDir, index)
+}
+
+// MatchesName reports whether the state belongs to the task called name. As
+// states missing from the index only know the normalized name of their task,
+// they can match the names of other tasks normalized the same way.
+func (s *State) MatchesName(name string) bool {
+	if s.Task != "" {
+		return s.Task == name
+	}
+	return s.normalizedName() == normalizeFilename(name)
+}
+
+// MatchesTask reports whether the state belongs to t, including the calls
+// matching its wildcards.
+func (s *State) MatchesTask(t *ast.Task) bool {
+	if s.Task != "" {
+		if s.Task == t.Task {
+			return true
+		}
+		match, _ := t.WildcardMatch(s.Task)
+		return match
+	}
+
+	parts := strings.Split(t.Task, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(normalizeFilename(part))
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(s.normalizedName())
+}
+
+// normalizedName returns the name of the task of the state, as normalized by
+// normalizeFilename.
+func (s *State) normalizedName() string {
+	if match := stateKeyRegexp.FindStringSubmatch(s.Key); match != nil {
+		return match[1]
+	}
+	return s.Key
+}
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"cmp"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -12,10 +13,13 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/go-task/task/v3"
+	"github.com/go-task/task/v3/args"
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/experiments"
+	"github.com/go-task/task/v3/internal/artifactcache"
 	"github.com/go-task/task/v3/internal/env"
 	"github.com/go-task/task/v3/internal/sort"
+	"github.com/go-task/task/v3/taskfile"
 	"github.com/go-task/task/v3/taskfile/ast"
 	"github.com/go-task/task/v3/taskrc"
 	taskrcast "github.com/go-task/task/v3/taskrc/ast"
@@ -25,6 +29,7 @@ const usage = `Usage: task [flags...] [task...]
 
 Runs the specified task(s). Falls back to the "default" task if no task name
 was specified, or lists all tasks if an unknown task name was specified.
+Run 'task <task> --help' to show the help of a task.
 
 Example: 'task hello' with the following 'Taskfile.yml' file will generate an
 'output.txt' file with the content "hello".
@@ -43,6 +48,10 @@ tasks:
 Options:
 `
 
+// Args are the arguments that are not flags of Task, in their order: task
+// names, variables and the arguments of tasks.
+var Args []string
+
 var (
 	Version             bool
 	Help                bool
@@ -51,6 +60,7 @@ var (
 	List                bool
 	ListAll             bool
 	ListJson            bool
+	ListArgs            bool
 	TaskSort            string
 	Status              bool
 	NoStatus            bool
@@ -79,13 +89,25 @@ var (
 	Download            bool
 	Offline             bool
 	TrustedHosts        []string
+	TrustedKeys         []string
+	RemoteAuth          map[string]*taskfile.HTTPAuth
 	ClearCache          bool
+	Remote              string
+	Lock                bool
+	UpdateLock          bool
+	PruneState          bool
+	ResetState          string
+	Eval                string
+	EvalTask            string
+	EvalVars            bool
 	Timeout             time.Duration
 	CacheExpiryDuration time.Duration
 	RemoteCacheDir      string
 	CACert              string
 	Cert                string
 	CertKey             string
+	ArtifactCacheURL    string
+	ArtifactCacheMode   string
 	Interactive         bool
 	TempDir             string
 )
@@ -112,6 +134,13 @@ func init() {
 	config, _ := taskrc.GetConfig(dir)
 	experiments.ParseWithConfig(dir, config)
 
+	// The keys remote Taskfiles can be signed with and the credentials of their
+	// hosts are only set in the taskrc
+	if config != nil {
+		TrustedKeys = config.Remote.TrustedKeys
+		RemoteAuth = remoteAuth(config.Remote.Auth)
+	}
+
 	// Parse the rest of the flags
 	log.SetFlags(0)
 	log.SetOutput(os.Stderr)
@@ -121,12 +150,13 @@ func init() {
 	}
 
 	pflag.BoolVar(&Version, "version", false, "Show Task version.")
-	pflag.BoolVarP(&Help, "help", "h", false, "Shows Task usage.")
+	pflag.BoolVarP(&Help, "help", "h", false, "Shows Task usage, or the help of the given tasks.")
 	pflag.BoolVarP(&Init, "init", "i", false, "Creates a new Taskfile.yml in the current folder.")
 	pflag.StringVar(&Completion, "completion", "", "Generates shell completion script.")
 	pflag.BoolVarP(&List, "list", "l", false, "Lists tasks with description of current Taskfile.")
 	pflag.BoolVarP(&ListAll, "list-all", "a", false, "Lists tasks with or without a description.")
 	pflag.BoolVarP(&ListJson, "json", "j", false, "Formats task list as JSON.")
+	pflag.BoolVar(&ListArgs, "list-args", false, "Lists the flags declared by the given tasks, one per line.")
 	pflag.StringVar(&TaskSort, "sort", "", "Changes the order of the tasks when listed. [default|alphanumeric|none].")
 	pflag.BoolVar(&Status, "status", false, "Exits with non-zero exit code if any of the given tasks is not up-to-date.")
 	pflag.BoolVar(&NoStatus, "no-status", false, "Ignore status when listing tasks as JSON")
@@ -160,11 +190,21 @@ func init() {
 	pflag.StringSliceVar(&TrustedHosts, "trusted-hosts", getConfig(config, "REMOTE_TRUSTED_HOSTS", func() *[]string { return &config.Remote.TrustedHosts }, nil), "List of trusted hosts for remote Taskfiles (comma-separated).")
 	pflag.DurationVar(&Timeout, "timeout", getConfig(config, "REMOTE_TIMEOUT", func() *time.Duration { return config.Remote.Timeout }, time.Second*10), "Timeout for downloading remote Taskfiles.")
 	pflag.BoolVar(&ClearCache, "clear-cache", false, "Clear the remote cache.")
+	pflag.StringVar(&Remote, "remote", "", "Manages the remote cache: list, show <url>, prune, trust <url> [checksum] or untrust <url>.")
+	pflag.BoolVar(&Lock, "lock", false, "Writes the Taskfile.lock with the resolved URL, commit and checksum of every remote Taskfile.")
+	pflag.BoolVar(&UpdateLock, "update-lock", false, "Accepts remote Taskfiles that drifted from the Taskfile.lock and updates it.")
+	pflag.BoolVar(&PruneState, "prune-state", false, "Removes the fingerprints of the tasks that no longer exist and the stale ones. Use with --dry to list them instead.")
+	pflag.StringVar(&ResetState, "reset", "", "Removes the fingerprints of the given task, so it runs again on its next call.")
+	pflag.StringVar(&Eval, "eval", "", "Renders the given template with the variables of the Taskfile, or of the task given by --task.")
+	pflag.StringVar(&EvalTask, "task", "", "Task whose variables are used by --eval and --vars.")
+	pflag.BoolVar(&EvalVars, "vars", false, "Prints the variables of the Taskfile, or of the task given by --task, as YAML or as JSON with --json.")
 	pflag.DurationVar(&CacheExpiryDuration, "expiry", getConfig(config, "REMOTE_CACHE_EXPIRY", func() *time.Duration { return config.Remote.CacheExpiry }, 0), "Expiry duration for cached remote Taskfiles.")
 	pflag.StringVar(&RemoteCacheDir, "remote-cache-dir", getConfig(config, "REMOTE_CACHE_DIR", func() *string { return config.Remote.CacheDir }, env.GetTaskEnv("REMOTE_DIR")), "Directory to cache remote Taskfiles.")
 	pflag.StringVar(&CACert, "cacert", getConfig(config, "REMOTE_CACERT", func() *string { return config.Remote.CACert }, ""), "Path to a custom CA certificate for HTTPS connections.")
 	pflag.StringVar(&Cert, "cert", getConfig(config, "REMOTE_CERT", func() *string { return config.Remote.Cert }, ""), "Path to a client certificate for HTTPS connections.")
 	pflag.StringVar(&CertKey, "cert-key", getConfig(config, "REMOTE_CERT_KEY", func() *string { return config.Remote.CertKey }, ""), "Path to a client certificate key for HTTPS connections.")
+	pflag.StringVar(&ArtifactCacheURL, "artifact-cache-url", getConfig(config, "ARTIFACT_CACHE_URL", func() *string { return config.ArtifactCache.URL }, ""), "Base URL of a shared HTTP cache for the generated files of tasks.")
+	pflag.StringVar(&ArtifactCacheMode, "artifact-cache-mode", getConfig(config, "ARTIFACT_CACHE_MODE", func() *string { return config.ArtifactCache.Mode }, ""), "Access mode of the artifact cache: [read-only|read-write].")
 
 	// Gentle force experiment will override the force flag and add a new force-all flag
 	if experiments.GentleForce.Enabled() {
@@ -174,7 +214,13 @@ func init() {
 		pflag.BoolVarP(&ForceAll, "force", "f", false, "Forces execution even when the task is up-to-date.")
 	}
 
-	pflag.Parse()
+	// Task names, variables and the flags declared by tasks are not known to
+	// pflag, so they are split from the flags of Task before parsing them
+	var flagArgs []string
+	flagArgs, Args = args.Split(pflag.CommandLine, os.Args[1:])
+	if err := pflag.CommandLine.Parse(flagArgs); err != nil {
+		log.Fatal(err)
+	}
 
 	// Auto-detect color based on environment when not explicitly configured
 	// Priority: CLI flag > TASK_COLOR env > taskrc config > NO_COLOR > FORCE_COLOR/CI > default
@@ -205,6 +251,10 @@ func Validate() error {
 		return errors.New("task: You can't set both --download and --offline flags")
 	}
 
+	if (Lock || UpdateLock) && Offline {
+		return errors.New("task: You can't set --lock or --update-lock with --offline")
+	}
+
 	if Download && ClearCache {
 		return errors.New("task: You can't set both --download and --clear-cache flags")
 	}
@@ -229,12 +279,20 @@ func Validate() error {
 		return errors.New("task: cannot use --list and --list-all at the same time")
 	}
 
-	if ListJson && !List && !ListAll {
-		return errors.New("task: --json only applies to --list or --list-all")
+	if ListJson && !List && !ListAll && !EvalVars && !Help && Remote == "" {
+		return errors.New("task: --json only applies to --list, --list-all, --vars, --help or --remote")
+	}
+
+	if Eval != "" && EvalVars {
+		return errors.New("task: You can't set both --eval and --vars")
+	}
+
+	if EvalTask != "" && Eval == "" && !EvalVars {
+		return errors.New("task: --task only applies to --eval or --vars")
 	}
 
-	if NoStatus && !ListJson {
-		return errors.New("task: --no-status only applies to --json with --list or --list-all")
+	if NoStatus && !ListJson && !Help {
+		return errors.New("task: --no-status only applies to --help or to --json with --list or --list-all")
 	}
 
 	if Nested && !ListJson {
@@ -246,6 +304,12 @@ func Validate() error {
 		return errors.New("task: --cert and --cert-key must be provided together")
 	}
 
+	switch ArtifactCacheMode {
+	case "", artifactcache.ModeReadOnly, artifactcache.ModeReadWrite:
+	default:
+		return fmt.Errorf("task: invalid --artifact-cache-mode %q, expected read-only or read-write", ArtifactCacheMode)
+	}
+
 	return nil
 }
 
@@ -283,14 +347,20 @@ func (o *flagsOption) ApplyToExecutor(e *task.Executor) {
 		task.WithForceAll(ForceAll),
 		task.WithInsecure(Insecure),
 		task.WithDownload(Download),
+		task.WithUpdateLock(Lock || UpdateLock),
 		task.WithOffline(Offline),
 		task.WithTrustedHosts(TrustedHosts),
+		task.WithTrustedKeys(TrustedKeys),
 		task.WithTimeout(Timeout),
 		task.WithCacheExpiryDuration(CacheExpiryDuration),
 		task.WithRemoteCacheDir(RemoteCacheDir),
 		task.WithCACert(CACert),
 		task.WithCert(Cert),
 		task.WithCertKey(CertKey),
+		task.WithRemoteAuth(RemoteAuth),
+		task.WithReservedFlags(reservedFlags()),
+		task.WithArtifactCacheURL(ArtifactCacheURL),
+		task.WithArtifactCacheMode(ArtifactCacheMode),
 		task.WithWatch(Watch),
 		task.WithVerbose(Verbose),
 		task.WithSilent(Silent),
@@ -311,6 +381,36 @@ func (o *flagsOption) ApplyToExecutor(e *task.Executor) {
 	)
 }
 
+// remoteAuth converts the credentials of the taskrc, expanding the environment
+// variables they reference.
+func remoteAuth(config map[string]*taskrcast.RemoteAuth) map[string]*taskfile.HTTPAuth {
+	if len(config) == 0 {
+		return nil
+	}
+	auth := make(map[string]*taskfile.HTTPAuth, len(config))
+	for host, a := range config {
+		if a == nil {
+			continue
+		}
+		auth[host] = &taskfile.HTTPAuth{
+			Token:    os.ExpandEnv(a.Token),
+			Username: os.ExpandEnv(a.Username),
+			Password: os.ExpandEnv(a.Password),
+		}
+	}
+	return auth
+}
+
+// reservedFlags returns the names of the flags of Task, which the args of tasks
+// can't declare.
+func reservedFlags() []string {
+	var names []string
+	pflag.VisitAll(func(f *pflag.Flag) {
+		names = append(names, f.Name)
+	})
+	return names
+}
+
 // getConfig extracts a config value with priority: env var > taskrc config > fallback
 func getConfig[T any](config *taskrcast.TaskRC, envKey string, fieldFunc func() *T, fallback T) T {
 	if envKey != "" {
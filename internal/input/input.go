@@ -3,6 +3,8 @@ package input
 import (
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/textinput"
@@ -19,6 +21,7 @@ var (
 	cursorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true) // cyan bold
 	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true) // green bold
 	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))            // gray
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))            // red
 )
 
 // Prompter handles interactive variable prompting
@@ -28,9 +31,28 @@ type Prompter struct {
 	Stderr io.Writer
 }
 
+// Field describes the variable to prompt a value for
+type Field struct {
+	Name    string
+	Desc    string
+	Example string
+	// Default is the value taken when the input is left empty, or the initial
+	// selection or answer
+	Default string
+	// Enum makes the prompt a selection between its values
+	Enum []string
+	// Confirm makes the prompt a yes/no question answered with true or false
+	Confirm bool
+	// Secret masks the value while it is typed
+	Secret bool
+	// Validate is called on the entered value, which is only accepted when it
+	// returns nil
+	Validate func(value string) error
+}
+
 // Text prompts the user for a text value
-func (p *Prompter) Text(varName string) (string, error) {
-	m := newTextModel(varName)
+func (p *Prompter) Text(field Field) (string, error) {
+	m := newTextModel(field)
 
 	prog := tea.NewProgram(m,
 		tea.WithInput(p.Stdin),
@@ -50,13 +72,13 @@ func (p *Prompter) Text(varName string) (string, error) {
 	return model.value, nil
 }
 
-// Select prompts the user to select from a list of options
-func (p *Prompter) Select(varName string, options []string) (string, error) {
-	if len(options) == 0 {
+// Select prompts the user to select from the enum of the field
+func (p *Prompter) Select(field Field) (string, error) {
+	if len(field.Enum) == 0 {
 		return "", errors.New("no options provided")
 	}
 
-	m := newSelectModel(varName, options)
+	m := newSelectModel(field)
 
 	prog := tea.NewProgram(m,
 		tea.WithInput(p.Stdin),
@@ -76,32 +98,82 @@ func (p *Prompter) Select(varName string, options []string) (string, error) {
 	return model.options[model.cursor], nil
 }
 
-// Prompt prompts for a variable value, using Select if enum is provided, Text otherwise
-func (p *Prompter) Prompt(varName string, enum []string) (string, error) {
-	if len(enum) > 0 {
-		return p.Select(varName, enum)
+// Confirm prompts the user for a yes/no answer, returned as "true" or "false"
+func (p *Prompter) Confirm(field Field) (string, error) {
+	m := newConfirmModel(field)
+
+	prog := tea.NewProgram(m,
+		tea.WithInput(p.Stdin),
+		tea.WithOutput(p.Stderr),
+	)
+
+	result, err := prog.Run()
+	if err != nil {
+		return "", err
+	}
+
+	model := result.(confirmModel)
+	if model.cancelled {
+		return "", ErrCancelled
+	}
+
+	return strconv.FormatBool(model.value), nil
+}
+
+// Prompt prompts for the value of a field, using Select if it has an enum,
+// Confirm if it is a confirmation and Text otherwise
+func (p *Prompter) Prompt(field Field) (string, error) {
+	switch {
+	case len(field.Enum) > 0:
+		return p.Select(field)
+	case field.Confirm:
+		return p.Confirm(field)
+	default:
+		return p.Text(field)
+	}
+}
+
+// renderDefault renders the default of a field next to its name
+func renderDefault(field Field) string {
+	if field.Default == "" {
+		return ""
+	}
+	if field.Secret {
+		return " (default: *****)"
 	}
-	return p.Text(varName)
+	return fmt.Sprintf(" (default: %s)", field.Default)
+}
+
+// renderDesc renders the description of a field below its prompt
+func renderDesc(field Field) string {
+	if field.Desc == "" {
+		return ""
+	}
+	return dimStyle.Render("  "+field.Desc) + "\n"
 }
 
 // textModel is the Bubble Tea model for text input
 type textModel struct {
-	varName   string
+	field     Field
 	textInput textinput.Model
 	value     string
+	err       error
 	cancelled bool
 	done      bool
 }
 
-func newTextModel(varName string) textModel {
+func newTextModel(field Field) textModel {
 	ti := textinput.New()
-	ti.Placeholder = ""
+	ti.Placeholder = field.Example
 	ti.CharLimit = 256
 	ti.SetWidth(40)
+	if field.Secret {
+		ti.EchoMode = textinput.EchoPassword
+	}
 	ti.Focus()
 
 	return textModel{
-		varName:   varName,
+		field:     field,
 		textInput: ti,
 	}
 }
@@ -119,7 +191,16 @@ func (m textModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.done = true
 			return m, tea.Quit
 		case "enter":
-			m.value = m.textInput.Value()
+			value := m.textInput.Value()
+			if value == "" {
+				value = m.field.Default
+			}
+			if m.field.Validate != nil {
+				if m.err = m.field.Validate(value); m.err != nil {
+					return m, nil
+				}
+			}
+			m.value = value
 			m.done = true
 			return m, tea.Quit
 		}
@@ -135,24 +216,95 @@ func (m textModel) View() tea.View {
 		return tea.NewView("")
 	}
 
-	prompt := promptStyle.Render(fmt.Sprintf("? Enter value for %s: ", m.varName))
-	return tea.NewView(prompt + m.textInput.View() + "\n")
+	prompt := promptStyle.Render(fmt.Sprintf("? Enter value for %s%s: ", m.field.Name, renderDefault(m.field)))
+	view := prompt + m.textInput.View() + "\n" + renderDesc(m.field)
+	if m.err != nil {
+		view += errorStyle.Render("  "+m.err.Error()) + "\n"
+	}
+	return tea.NewView(view)
+}
+
+// confirmModel is the Bubble Tea model for yes/no questions
+type confirmModel struct {
+	field     Field
+	value     bool
+	cancelled bool
+	done      bool
+}
+
+func newConfirmModel(field Field) confirmModel {
+	value, _ := strconv.ParseBool(field.Default)
+	return confirmModel{field: field, value: value}
+}
+
+func (m confirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.Keystroke() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			m.done = true
+			return m, tea.Quit
+		case "left", "right", "tab", "h", "l":
+			m.value = !m.value
+		case "y", "Y":
+			m.value = true
+			m.done = true
+			return m, tea.Quit
+		case "n", "N":
+			m.value = false
+			m.done = true
+			return m, tea.Quit
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m confirmModel) View() tea.View {
+	if m.done {
+		return tea.NewView("")
+	}
+
+	yes, no := "yes", "no"
+	if m.value {
+		yes = selectedStyle.Render(yes)
+	} else {
+		no = selectedStyle.Render(no)
+	}
+
+	var b strings.Builder
+	b.WriteString(promptStyle.Render(fmt.Sprintf("? %s: ", m.field.Name)))
+	b.WriteString(yes + " / " + no + "\n")
+	b.WriteString(renderDesc(m.field))
+	b.WriteString(dimStyle.Render("  (y/n, ←/→ to toggle, enter to confirm, esc to cancel)"))
+
+	return tea.NewView(b.String())
 }
 
 // selectModel is the Bubble Tea model for selection
 type selectModel struct {
 	varName   string
+	desc      string
 	options   []string
 	cursor    int
 	cancelled bool
 	done      bool
 }
 
-func newSelectModel(varName string, options []string) selectModel {
+func newSelectModel(field Field) selectModel {
 	return selectModel{
-		varName: varName,
-		options: options,
-		cursor:  0,
+		varName: field.Name,
+		desc:    field.Desc,
+		options: field.Enum,
+		cursor:  max(slices.Index(field.Enum, field.Default), 0),
 	}
 }
 
@@ -194,6 +346,7 @@ func (m selectModel) View() tea.View {
 
 	b.WriteString(promptStyle.Render(fmt.Sprintf("? Select value for %s:", m.varName)))
 	b.WriteString("\n")
+	b.WriteString(renderDesc(Field{Desc: m.desc}))
 
 	for i, opt := range m.options {
 		if i == m.cursor {
@@ -137,6 +137,8 @@ type Logger struct {
 	Color      bool
 	AssumeYes  bool
 	AssumeTerm bool // Used for testing
+
+	stdin *bufio.Reader
 }
 
 // Outf prints stuff to STDOUT.
@@ -202,8 +204,7 @@ func (l *Logger) Prompt(color Color, prompt string, defaultValue string, continu
 
 	l.Outf(color, "%s [%s/%s]: ", prompt, strings.ToLower(continueValues[0]), strings.ToUpper(defaultValue))
 
-	reader := bufio.NewReader(l.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := l.readLine()
 	if err != nil {
 		return err
 	}
@@ -216,6 +217,44 @@ func (l *Logger) Prompt(color Color, prompt string, defaultValue string, continu
 	return nil
 }
 
+// PromptChoice prompts the user to choose one of the given values and returns
+// it. An empty answer chooses defaultValue, which is shown in uppercase. Unlike
+// [Logger.Prompt], it does not assume yes.
+func (l *Logger) PromptChoice(color Color, prompt string, defaultValue string, values ...string) (string, error) {
+	if !l.AssumeTerm && !term.IsTerminal() {
+		return "", ErrNoTerminal
+	}
+
+	choices := make([]string, len(values))
+	for i, value := range values {
+		choices[i] = strings.ToLower(value)
+		if value == defaultValue {
+			choices[i] = strings.ToUpper(value)
+		}
+	}
+	l.Outf(color, "%s [%s]: ", prompt, strings.Join(choices, "/"))
+
+	input, err := l.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	input = strings.TrimSpace(strings.ToLower(input))
+	if input == "" {
+		return defaultValue, nil
+	}
+	return input, nil
+}
+
+// readLine reads a line from Stdin. The reader is kept across prompts, so the
+// input it already buffered, like piped answers, is not lost.
+func (l *Logger) readLine() (string, error) {
+	if l.stdin == nil {
+		l.stdin = bufio.NewReader(l.Stdin)
+	}
+	return l.stdin.ReadString('\n')
+}
+
 func (l *Logger) PrintExperiments() error {
 	w := tabwriter.NewWriter(l.Stdout, 0, 8, 0, ' ', 0)
 	for _, x := range experiments.List() {
@@ -0,0 +1,59 @@
+package summary
+
+import (
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// PrintTaskHelp prints how to call t: its description and summary, usage,
+// args, required variables, aliases, deps and platforms, followed by whether
+// it is up to date unless upToDate is nil.
+func PrintTaskHelp(l *logger.Logger, t *ast.Task, upToDate *bool) {
+	printTaskName(l, t)
+	printTaskHelpText(l, t)
+	printTaskUsage(l, t)
+	printTaskArgs(l, t)
+	printTaskRequires(l, t)
+	printTaskAliases(l, t)
+	printTaskDependencies(l, t)
+	printTaskPlatforms(l, t)
+	printTaskStatus(l, upToDate)
+}
+
+func printTaskHelpText(l *logger.Logger, t *ast.Task) {
+	switch {
+	case hasDescription(t) && hasSummary(t) && t.Summary != t.Desc:
+		printTaskDescription(l, t)
+		l.Outf(logger.Default, "\n")
+		printTaskSummary(l, t)
+	case hasDescription(t):
+		printTaskDescription(l, t)
+	case hasSummary(t):
+		printTaskSummary(l, t)
+	default:
+		printNoDescriptionOrSummary(l)
+	}
+}
+
+func printTaskPlatforms(l *logger.Logger, t *ast.Task) {
+	if len(t.Platforms) == 0 {
+		return
+	}
+	l.Outf(logger.Default, "\n")
+	l.Outf(logger.Default, "platforms:\n")
+	for _, p := range t.Platforms {
+		l.Outf(logger.Default, " - %s\n", p)
+	}
+}
+
+func printTaskStatus(l *logger.Logger, upToDate *bool) {
+	if upToDate == nil {
+		return
+	}
+	l.Outf(logger.Default, "\n")
+	if *upToDate {
+		l.Outf(logger.Green, "status: up to date\n")
+		return
+	}
+	l.Outf(logger.Yellow, "status: not up to date\n")
+}
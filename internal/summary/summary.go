@@ -34,6 +34,10 @@ func PrintTask(l *logger.Logger, t *ast.Task) {
 	printTaskVars(l, t)
 	printTaskEnv(l, t)
 	printTaskRequires(l, t)
+	if len(t.Args) > 0 {
+		printTaskUsage(l, t)
+		printTaskArgs(l, t)
+	}
 	printTaskDependencies(l, t)
 	printTaskAliases(l, t)
 	printTaskCommands(l, t)
@@ -163,6 +167,21 @@ func printTaskVars(l *logger.Logger, t *ast.Task) {
 		if !isEnvVar(key, osEnvVars) && !taskfileEnvVars[key] {
 			formattedValue := formatVarValue(value)
 			l.Outf(logger.Yellow, "  %s: %s\n", key, formattedValue)
+			if l.Verbose {
+				printVarOrigin(l, value.Origin)
+			}
+		}
+	}
+}
+
+// printVarOrigin prints where the value of a variable comes from and the
+// values it overrode.
+func printVarOrigin(l *logger.Logger, origin *ast.VarOrigin) {
+	for i, o := range origin.Chain() {
+		if i == 0 {
+			l.Outf(logger.Default, "    from %s\n", o)
+		} else {
+			l.Outf(logger.Default, "    overrides %s\n", o)
 		}
 	}
 }
@@ -257,19 +276,57 @@ func printTaskRequires(l *logger.Logger, t *ast.Task) {
 	l.Outf(logger.Default, "  vars:\n")
 
 	for _, v := range t.Requires.Vars {
-		if v.Enum != nil && len(v.Enum.Value) > 0 {
-			l.Outf(logger.Yellow, "    - %s:\n", v.Name)
-			l.Outf(logger.Yellow, "        enum:\n")
-			for _, enumValue := range v.Enum.Value {
-				l.Outf(logger.Yellow, "          - %s\n", enumValue)
-			}
-		} else if v.Enum != nil && v.Enum.Ref != "" {
-			l.Outf(logger.Yellow, "    - %s:\n", v.Name)
-			l.Outf(logger.Yellow, "        enum:\n")
-			l.Outf(logger.Yellow, "          ref: %s\n", v.Enum.Ref)
-		} else {
-			l.Outf(logger.Yellow, "    - %s\n", v.Name)
+		printRequiredVar(l, v.Name, v, "    ")
+	}
+}
+
+func printTaskUsage(l *logger.Logger, t *ast.Task) {
+	l.Outf(logger.Default, "\n")
+	l.Outf(logger.Default, "usage: task %s\n", strings.TrimSpace(t.Name()+" "+ast.ArgsUsage(t.Args)))
+}
+
+func printTaskArgs(l *logger.Logger, t *ast.Task) {
+	if len(t.Args) == 0 {
+		return
+	}
+
+	l.Outf(logger.Default, "args:\n")
+
+	for _, arg := range t.Args {
+		printRequiredVar(l, arg.Usage(), &arg.VarsWithValidation, "  ")
+	}
+}
+
+// printRequiredVar prints the required variable v as a list item called name,
+// with its details when it has any.
+func printRequiredVar(l *logger.Logger, name string, v *ast.VarsWithValidation, indent string) {
+	hasEnum := v.Enum != nil && (len(v.Enum.Value) > 0 || v.Enum.Ref != "")
+	if !hasEnum && v.Desc == "" && v.Type == "" && !v.HasDefault() && v.Example == "" {
+		l.Outf(logger.Yellow, "%s- %s\n", indent, name)
+		return
+	}
+	l.Outf(logger.Yellow, "%s- %s:\n", indent, name)
+	if v.Desc != "" {
+		l.Outf(logger.Yellow, "%s    desc: %s\n", indent, v.Desc)
+	}
+	if v.Type != "" {
+		l.Outf(logger.Yellow, "%s    type: %s\n", indent, v.Type)
+	}
+	if v.HasDefault() {
+		l.Outf(logger.Yellow, "%s    default: %s\n", indent, formatVarValue(ast.Var{Value: v.Default, Secret: v.Secret}))
+	}
+	if v.Example != "" {
+		l.Outf(logger.Yellow, "%s    example: %s\n", indent, v.Example)
+	}
+	switch {
+	case v.Enum != nil && len(v.Enum.Value) > 0:
+		l.Outf(logger.Yellow, "%s    enum:\n", indent)
+		for _, enumValue := range v.Enum.Value {
+			l.Outf(logger.Yellow, "%s      - %s\n", indent, enumValue)
 		}
+	case v.Enum != nil && v.Enum.Ref != "":
+		l.Outf(logger.Yellow, "%s    enum:\n", indent)
+		l.Outf(logger.Yellow, "%s      ref: %s\n", indent, v.Enum.Ref)
 	}
 }
 
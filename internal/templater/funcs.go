@@ -1,22 +1,33 @@
 package templater
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
 	"maps"
 	"math/rand/v2"
 	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/uuid"
+	"github.com/zeebo/xxh3"
 	"go.yaml.in/yaml/v3"
 	"mvdan.cc/sh/v3/shell"
 	"mvdan.cc/sh/v3/syntax"
 
 	sprig "github.com/go-task/slim-sprig/v3"
 	"github.com/go-task/template"
+
+	"github.com/go-task/task/v3/internal/execext"
 )
 
 var templateFuncs template.FuncMap
@@ -45,9 +56,14 @@ func init() {
 		"mustFromYaml": mustFromYaml,
 		"toYaml":       toYaml,
 		"mustToYaml":   mustToYaml,
+		"fromToml":     fromToml,
+		"mustFromToml": mustFromToml,
+		"fromIni":      fromIni,
+		"mustFromIni":  mustFromIni,
 		"uuid":         uuid.New,
 		"randIntN":     rand.IntN,
 	}
+	maps.Copy(taskFuncs, fsFuncs(""))
 
 	// aliases
 	taskFuncs["q"] = taskFuncs["shellQuote"]
@@ -61,6 +77,100 @@ func init() {
 	maps.Copy(templateFuncs, taskFuncs)
 }
 
+// newTemplate returns a template with the template functions, the ones
+// accessing the filesystem resolving relative paths from dir. An empty dir
+// stands for the working directory.
+func newTemplate(name string, dir string) *template.Template {
+	t := template.New(name).Funcs(templateFuncs)
+	if dir != "" {
+		t = t.Funcs(fsFuncs(dir))
+	}
+	return t
+}
+
+// fsFuncs returns the template functions accessing the filesystem, which
+// resolve relative paths from dir.
+func fsFuncs(dir string) template.FuncMap {
+	path := func(name string) string {
+		if dir == "" || filepath.IsAbs(name) {
+			return name
+		}
+		return filepath.Join(dir, name)
+	}
+	return template.FuncMap{
+		"readFile": func(name string) (string, error) {
+			data, err := os.ReadFile(path(name))
+			return string(data), err
+		},
+		"fileExists": func(name string) bool {
+			_, err := os.Stat(path(name))
+			return err == nil
+		},
+		"isDir": func(name string) bool {
+			info, err := os.Stat(path(name))
+			return err == nil && info.IsDir()
+		},
+		"glob": func(patterns ...string) ([]string, error) {
+			return glob(dir, patterns)
+		},
+		"sha256File": func(name string) (string, error) {
+			return hashFile(sha256.New(), path(name))
+		},
+		"xxh3File": func(name string) (string, error) {
+			return hashFile(xxh3.New128(), path(name))
+		},
+	}
+}
+
+// glob returns the files matching the patterns, relative to dir, or to the
+// working directory if dir is empty, and sorted. Like sources, patterns are
+// evaluated in order, and the ones starting with "!" exclude the files matched
+// so far.
+func glob(dir string, patterns []string) ([]string, error) {
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		dir = wd
+	}
+	matched := make(map[string]bool)
+	for _, pattern := range patterns {
+		pattern, negate := strings.CutPrefix(pattern, "!")
+		files, err := execext.Glob(dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			matched[file] = !negate
+		}
+	}
+	files := make([]string, 0, len(matched))
+	for file, ok := range matched {
+		if !ok {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, file); err == nil {
+			file = filepath.ToSlash(rel)
+		}
+		files = append(files, file)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func hashFile(h hash.Hash, name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func goos() string {
 	return runtime.GOOS
 }
@@ -148,3 +258,57 @@ func mustToYaml(v any) (string, error) {
 	}
 	return string(output), nil
 }
+
+func fromToml(v string) any {
+	output, _ := mustFromToml(v)
+	return output
+}
+
+func mustFromToml(v string) (any, error) {
+	var output map[string]any
+	_, err := toml.Decode(v, &output)
+	return output, err
+}
+
+func fromIni(v string) any {
+	output, _ := mustFromIni(v)
+	return output
+}
+
+// mustFromIni parses an INI document into a map of its keys. The keys of
+// each section are in a nested map named after it.
+func mustFromIni(v string) (any, error) {
+	output := map[string]any{}
+	current := output
+	scanner := bufio.NewScanner(strings.NewReader(v))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || text[0] == ';' || text[0] == '#':
+			continue
+		case text[0] == '[':
+			name, ok := strings.CutSuffix(text[1:], "]")
+			if !ok {
+				return nil, fmt.Errorf("ini: line %d: unterminated section name", line)
+			}
+			name = strings.TrimSpace(name)
+			section, ok := output[name].(map[string]any)
+			if !ok {
+				section = map[string]any{}
+				output[name] = section
+			}
+			current = section
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, fmt.Errorf("ini: line %d: expected key = value", line)
+			}
+			value = strings.TrimSpace(value)
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			current[strings.TrimSpace(key)] = value
+		}
+	}
+	return output, scanner.Err()
+}
@@ -1,6 +1,13 @@
 package templater
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
 
 func TestJoinUrl(t *testing.T) {
 	t.Parallel()
@@ -29,3 +36,100 @@ func TestJoinUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestFsFuncs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"VERSION":          "1.2.3\n",
+		"src/main.go":      "package main\n",
+		"src/main_test.go": "package main\n",
+		"src/gen/gen.go":   "package gen\n",
+		"config/app.json":  `{"port": 8080}`,
+		"config/app.toml":  "port = 8080\n",
+		"config/app.ini":   "[server]\nport = 8080\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"readFile", `{{readFile "VERSION" | trim}}`, "1.2.3"},
+		{"fileExists", `{{fileExists "VERSION"}} {{fileExists "missing"}}`, "true false"},
+		{"isDir", `{{isDir "src"}} {{isDir "VERSION"}} {{isDir "missing"}}`, "true false false"},
+		{"glob", `{{glob "src/**/*.go" "!src/**/*_test.go" | join " "}}`, "src/gen/gen.go src/main.go"},
+		{"sha256File", `{{sha256File "VERSION"}}`, "d82f34ae9aa41bc4a0cb529a1ac0898fed09d6b479fb1cc44cb66c34f15ee84d"},
+		{"fromJson", `{{(readFile "config/app.json" | fromJson).port}}`, "8080"},
+		{"fromToml", `{{(readFile "config/app.toml" | fromToml).port}}`, "8080"},
+		{"fromIni", `{{(readFile "config/app.ini" | fromIni).server.port}}`, "8080"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &Cache{Vars: ast.NewVars(), Dir: dir}
+			got := Replace(tt.template, cache)
+			if err := cache.Err(); err != nil {
+				t.Fatalf("Replace(%q) unexpected error: %v", tt.template, err)
+			}
+			if got != tt.want {
+				t.Errorf("Replace(%q) = %q; want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+// nolint:paralleltest // cannot run in parallel
+func TestGlobWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	// Without a directory, paths are relative to the working directory too.
+	cache := &Cache{Vars: ast.NewVars()}
+	got := Replace(`{{glob "src/*.go" | join " "}}`, cache)
+	if err := cache.Err(); err != nil {
+		t.Fatalf("Replace unexpected error: %v", err)
+	}
+	if got != "src/main.go" {
+		t.Errorf("glob = %q; want %q", got, "src/main.go")
+	}
+}
+
+func TestFromIni(t *testing.T) {
+	t.Parallel()
+
+	got, err := mustFromIni("; comment\nname = app\n\n[server]\nhost = \"localhost\"\nport=8080\n[server]\ndebug = true\n")
+	if err != nil {
+		t.Fatalf("mustFromIni unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  "8080",
+			"debug": "true",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mustFromIni = %v; want %v", got, want)
+	}
+
+	if _, err := mustFromIni("[server\n"); err == nil {
+		t.Error("mustFromIni with an unterminated section: expected an error")
+	}
+}
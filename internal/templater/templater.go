@@ -6,8 +6,6 @@ import (
 	"maps"
 	"strings"
 
-	"github.com/go-task/template"
-
 	"github.com/go-task/task/v3/internal/deepcopy"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
@@ -18,6 +16,9 @@ import (
 // return the zero value.
 type Cache struct {
 	Vars *ast.Vars
+	// Dir is the directory the functions accessing the filesystem resolve
+	// relative paths from. Defaults to the working directory.
+	Dir string
 
 	cacheMap map[string]any
 	err      error
@@ -45,7 +46,7 @@ func ResolveRef(ref string, cache *Cache) any {
 	if ref == "." {
 		return cache.cacheMap
 	}
-	t, err := template.New("resolver").Funcs(templateFuncs).Parse(fmt.Sprintf("{{%s}}", ref))
+	t, err := newTemplate("resolver", cache.Dir).Parse(fmt.Sprintf("{{%s}}", ref))
 	if err != nil {
 		cache.err = err
 		return nil
@@ -93,7 +94,7 @@ func ReplaceWithExtra[T any](v T, cache *Cache, extra map[string]any) T {
 		if !strings.Contains(v, "{{") {
 			return v, nil
 		}
-		tpl, err := template.New("").Funcs(templateFuncs).Parse(v)
+		tpl, err := newTemplate("", cache.Dir).Parse(v)
 		if err != nil {
 			return v, err
 		}
@@ -121,6 +122,7 @@ func ReplaceGlobs(globs []*ast.Glob, cache *Cache) []*ast.Glob {
 		new[i] = &ast.Glob{
 			Glob:   Replace(g.Glob, cache),
 			Negate: g.Negate,
+			Task:   Replace(g.Task, cache),
 		}
 	}
 	return new
@@ -132,7 +134,7 @@ func ReplaceVar(v ast.Var, cache *Cache) ast.Var {
 
 func ReplaceVarWithExtra(v ast.Var, cache *Cache, extra map[string]any) ast.Var {
 	if v.Ref != "" {
-		return ast.Var{Value: ResolveRef(v.Ref, cache), Secret: v.Secret}
+		return ast.Var{Value: ResolveRef(v.Ref, cache), Secret: v.Secret, Origin: v.Origin}
 	}
 	return ast.Var{
 		Value:  ReplaceWithExtra(v.Value, cache, extra),
@@ -141,6 +143,7 @@ func ReplaceVarWithExtra(v ast.Var, cache *Cache, extra map[string]any) ast.Var
 		Ref:    v.Ref,
 		Dir:    v.Dir,
 		Secret: v.Secret,
+		Origin: v.Origin,
 	}
 }
 
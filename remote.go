@@ -0,0 +1,290 @@
+package task
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Ladicle/tabwriter"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/logger"
+	"github.com/go-task/task/v3/taskfile"
+)
+
+// ManageRemoteCache runs a command on the cache of remote Taskfiles:
+//
+//   - list: lists the cached Taskfiles
+//   - show <url|key>: prints a cached Taskfile
+//   - prune: removes the cached Taskfiles and the extracted archives older than
+//     the cache expiry. With Dry, they are only listed
+//   - trust <url> [checksum]: trusts the Taskfile at url, so it is read without
+//     a prompt. Without a checksum, the Taskfile is downloaded and trusted as is
+//   - untrust <url>: revokes the trust of the Taskfile at url
+//
+// It does not need the Taskfile to be read, so it can be called before
+// [Executor.Setup].
+func (e *Executor) ManageRemoteCache(command string, asJSON bool, args ...string) error {
+	if err := e.setupRemoteCache(); err != nil {
+		return err
+	}
+
+	switch command {
+	case "list":
+		return e.listRemoteCache(asJSON)
+	case "show":
+		if len(args) != 1 {
+			return fmt.Errorf("task: --remote show expects the URL or the cache key of a Taskfile")
+		}
+		return e.showRemoteCache(args[0])
+	case "prune":
+		return e.pruneRemoteCache()
+	case "trust":
+		if len(args) != 1 && len(args) != 2 {
+			return fmt.Errorf("task: --remote trust expects the URL of a Taskfile and optionally its checksum")
+		}
+		return e.trustRemote(args[0], args[1:]...)
+	case "untrust":
+		if len(args) != 1 {
+			return fmt.Errorf("task: --remote untrust expects the URL of a Taskfile")
+		}
+		return e.untrustRemote(args[0])
+	default:
+		return fmt.Errorf("task: unknown --remote command %q, expected one of list, show, prune, trust or untrust", command)
+	}
+}
+
+// promptRemoteDiff prints the diff of a remote Taskfile that changed, then
+// prompts to trust it. The user can also view the diff in a pager.
+func (e *Executor) promptRemoteDiff(prompt string, diff string) error {
+	if e.Logger.AssumeYes {
+		return e.Logger.Prompt(logger.Yellow, prompt, "n", "y", "yes")
+	}
+
+	// The diff is printed first, so it is shown even without a terminal
+	e.Logger.Outf(logger.Default, "%s\n", diff)
+	for {
+		choice, err := e.Logger.PromptChoice(logger.Yellow, prompt+" (d: view the diff in a pager)", "n", "y", "n", "d")
+		if err != nil {
+			return err
+		}
+		switch choice {
+		case "y", "yes":
+			return nil
+		case "d":
+			e.page(diff)
+		default:
+			return logger.ErrPromptCancelled
+		}
+	}
+}
+
+// page shows s in the pager set by $PAGER, or in less.
+func (e *Executor) page(s string) {
+	pager := strings.Fields(cmp.Or(strings.TrimSpace(os.Getenv("PAGER")), "less -R"))
+	cmd := exec.CommandContext(context.Background(), pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(s + "\n")
+	cmd.Stdout = e.Stdout
+	cmd.Stderr = e.Stderr
+	if err := cmd.Run(); err != nil {
+		e.Logger.Warnf("task: Failed to run the pager %q: %v\n", strings.Join(pager, " "), err)
+	}
+}
+
+func (e *Executor) setupRemoteCache() error {
+	e.setupStdFiles()
+	e.setupLogger()
+	e.setupNetrc()
+	if e.Taskfile == nil {
+		// The cache lives next to the Taskfile, if there is one
+		_, err := e.getRootNode()
+		if _, ok := errors.AsType[errors.TaskfileNotFoundError](err); !ok && err != nil {
+			return err
+		}
+	}
+	return e.setupTempDir()
+}
+
+func (e *Executor) listRemoteCache(asJSON bool) error {
+	entries, err := taskfile.ReadCache(e.TempDir.Remote)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if entries == nil {
+			entries = []*taskfile.CacheEntry{}
+		}
+		encoder := json.NewEncoder(e.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		e.Logger.Outf(logger.Yellow, "task: No remote Taskfiles in the cache\n")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(e.Stdout, 0, 8, 3, ' ', 0)
+	e.Logger.FOutf(w, logger.Default, "URL\tKEY\tDOWNLOADED\tCHECKSUM\tSIZE\n")
+	for _, entry := range entries {
+		downloaded := "-"
+		if !entry.Timestamp.IsZero() {
+			downloaded = entry.Timestamp.Local().Format("2006-01-02 15:04:05")
+		}
+		checksum := entry.Checksum
+		if len(checksum) > 12 {
+			checksum = checksum[:12]
+		}
+		e.Logger.FOutf(w, logger.Green, "%s", cmp.Or(entry.URL, "-"))
+		e.Logger.FOutf(w, logger.Default, "\t%s\t%s\t%s\t%d B\n", entry.Key, downloaded, cmp.Or(checksum, "-"), entry.Size)
+	}
+	return w.Flush()
+}
+
+func (e *Executor) showRemoteCache(location string) error {
+	entry, err := e.findRemoteCacheEntry(location)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.Path == "" {
+		return fmt.Errorf("task: No cached Taskfile for %q", location)
+	}
+	b, err := os.ReadFile(entry.Path)
+	if err != nil {
+		return err
+	}
+	_, err = e.Stdout.Write(b)
+	return err
+}
+
+func (e *Executor) pruneRemoteCache() error {
+	if e.CacheExpiryDuration <= 0 {
+		return fmt.Errorf("task: --remote prune requires a cache expiry, set with --expiry or remote.cache-expiry")
+	}
+
+	entries, err := taskfile.ReadCache(e.TempDir.Remote)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Expired(e.CacheExpiryDuration) {
+			continue
+		}
+		if e.Dry {
+			e.Logger.Outf(logger.Default, "Would remove %s (%s)\n", filepathext.TryAbsToRel(entry.Path), cmp.Or(entry.URL, "-"))
+			continue
+		}
+		if err := entry.Remove(); err != nil {
+			return err
+		}
+		e.Logger.Outf(logger.Default, "Removed %s (%s)\n", filepathext.TryAbsToRel(entry.Path), cmp.Or(entry.URL, "-"))
+	}
+
+	archives, err := taskfile.ReadExtractedArchives(e.TempDir.Remote)
+	if err != nil {
+		return err
+	}
+	for _, archive := range archives {
+		if !archive.Expired(e.CacheExpiryDuration) {
+			continue
+		}
+		if e.Dry {
+			e.Logger.Outf(logger.Default, "Would remove %s (extracted archive)\n", filepathext.TryAbsToRel(archive.Path))
+			continue
+		}
+		if err := archive.Remove(); err != nil {
+			return err
+		}
+		e.Logger.Outf(logger.Default, "Removed %s (extracted archive)\n", filepathext.TryAbsToRel(archive.Path))
+	}
+	return nil
+}
+
+func (e *Executor) trustRemote(location string, checksums ...string) error {
+	node, err := e.newRemoteNode(location)
+	if err != nil {
+		return err
+	}
+	cache := taskfile.NewCacheNode(node, e.TempDir.Remote)
+
+	if len(checksums) > 0 {
+		if err := cache.WriteChecksum(checksums[0]); err != nil {
+			return err
+		}
+		e.Logger.Outf(logger.Green, "task: Trusted %s (%s)\n", location, checksums[0])
+		return nil
+	}
+
+	// Download the Taskfile and trust what it currently is
+	ctx, cf := context.WithTimeout(context.Background(), e.Timeout)
+	defer cf()
+	b, err := node.ReadContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &errors.TaskfileNetworkTimeoutError{URI: location, Timeout: e.Timeout}
+	}
+	if err != nil {
+		return err
+	}
+	checksum, err := cache.Trust(b)
+	if err != nil {
+		return err
+	}
+	e.Logger.Outf(logger.Green, "task: Trusted %s (%s)\n", location, checksum)
+	return nil
+}
+
+func (e *Executor) untrustRemote(location string) error {
+	node, err := e.newRemoteNode(location)
+	if err != nil {
+		return err
+	}
+	if err := taskfile.NewCacheNode(node, e.TempDir.Remote).RemoveChecksum(); err != nil {
+		return err
+	}
+	e.Logger.Outf(logger.Green, "task: Untrusted %s\n", location)
+	return nil
+}
+
+// findRemoteCacheEntry returns the cache entry of the remote Taskfile at
+// location, which is either its URL or its cache key. It returns nil if there
+// is none.
+func (e *Executor) findRemoteCacheEntry(location string) (*taskfile.CacheEntry, error) {
+	key := location
+	if node, err := e.newRemoteNode(location); err == nil {
+		key = node.CacheKey()
+	}
+	entries, err := taskfile.ReadCache(e.TempDir.Remote)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Key == key || entry.URL == location {
+			return entry, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *Executor) newRemoteNode(location string) (taskfile.RemoteNode, error) {
+	node, err := taskfile.NewNode(location, e.Dir, e.Insecure,
+		taskfile.WithCACert(e.CACert),
+		taskfile.WithCert(e.Cert),
+		taskfile.WithCertKey(e.CertKey),
+		taskfile.WithAuth(e.RemoteAuth),
+		taskfile.WithNetrc(e.netrc),
+	)
+	if err != nil {
+		return nil, err
+	}
+	remote, ok := node.(taskfile.RemoteNode)
+	if !ok {
+		return nil, fmt.Errorf("task: %q is not a remote Taskfile", location)
+	}
+	return remote, nil
+}
@@ -1,14 +1,18 @@
 package task
 
 import (
+	"fmt"
+	"os"
 	"slices"
 
 	"github.com/elliotchance/orderedmap/v3"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/input"
 	"github.com/go-task/task/v3/internal/templater"
 	"github.com/go-task/task/v3/internal/term"
+	"github.com/go-task/task/v3/taskfile"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -35,7 +39,7 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 
 	// Collect all missing vars from the dependency tree
 	visited := make(map[string]bool)
-	varsMap := orderedmap.NewOrderedMap[string, *ast.VarsWithValidation]()
+	fields := orderedmap.NewOrderedMap[string, input.Field]()
 
 	var collect func(call *Call) error
 	collect = func(call *Call) error {
@@ -45,8 +49,8 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 		}
 
 		for _, v := range getMissingRequiredVars(compiledTask) {
-			if !varsMap.Has(v.Name) {
-				varsMap.Set(v.Name, resolveEnumRefForPrompt(v, compiledTask.Vars))
+			if !fields.Has(v.Name) {
+				fields.Set(v.Name, promptField(resolveEnumRefForPrompt(v, compiledTask.Vars), compiledTask.Dir))
 			}
 		}
 
@@ -76,22 +80,22 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 		}
 	}
 
-	if varsMap.Len() == 0 {
+	if fields.Len() == 0 {
 		return nil
 	}
 
 	prompter := e.newPrompter()
 	e.promptedVars = ast.NewVars()
 
-	for v := range varsMap.Values() {
-		value, err := prompter.Prompt(v.Name, getEnumValues(v.Enum))
+	for field := range fields.Values() {
+		value, err := prompter.Prompt(field)
 		if err != nil {
 			if errors.Is(err, input.ErrCancelled) {
 				return &errors.TaskCancelledByUserError{TaskName: "interactive prompt"}
 			}
 			return err
 		}
-		e.promptedVars.Set(v.Name, ast.Var{Value: value})
+		e.promptedVars.Set(field.Name, ast.Var{Value: value, Secret: field.Secret})
 	}
 
 	return nil
@@ -101,7 +105,7 @@ func (e *Executor) promptDepsVars(calls []*Call) error {
 // Used for sequential task calls (cmds) where we can prompt just-in-time.
 // Returns true if any vars were prompted (caller should recompile the task).
 func (e *Executor) promptTaskVars(t *ast.Task, call *Call) (bool, error) {
-	if !e.canPrompt() || t.Requires == nil || len(t.Requires.Vars) == 0 {
+	if !e.canPrompt() || len(t.RequiredVars()) == 0 {
 		return false, nil
 	}
 
@@ -123,7 +127,7 @@ func (e *Executor) promptTaskVars(t *ast.Task, call *Call) (bool, error) {
 	prompter := e.newPrompter()
 
 	for _, v := range missing {
-		value, err := prompter.Prompt(v.Name, getEnumValues(v.Enum))
+		value, err := prompter.Prompt(promptField(v, t.Dir))
 		if err != nil {
 			if errors.Is(err, input.ErrCancelled) {
 				return false, &errors.TaskCancelledByUserError{TaskName: t.Name()}
@@ -135,25 +139,70 @@ func (e *Executor) promptTaskVars(t *ast.Task, call *Call) (bool, error) {
 		if call.Vars == nil {
 			call.Vars = ast.NewVars()
 		}
-		call.Vars.Set(v.Name, ast.Var{Value: value})
+		call.Vars.Set(v.Name, ast.Var{Value: value, Secret: v.Secret})
 
 		// Cache for reuse by other tasks
 		if e.promptedVars == nil {
 			e.promptedVars = ast.NewVars()
 		}
-		e.promptedVars.Set(v.Name, ast.Var{Value: value})
+		e.promptedVars.Set(v.Name, ast.Var{Value: value, Secret: v.Secret})
 	}
 
 	return true, nil
 }
 
+// promptField returns the field to prompt for the required variable v, whose
+// relative paths are resolved from dir.
+func promptField(v *ast.VarsWithValidation, dir string) input.Field {
+	field := input.Field{
+		Name:    v.Name,
+		Desc:    v.Desc,
+		Example: v.Example,
+		Enum:    getEnumValues(v.Enum),
+		Confirm: v.Type == ast.RequiredVarTypeBool,
+		Secret:  v.Secret,
+		Validate: func(value string) error {
+			return v.Validate(value, dir)
+		},
+	}
+	if v.HasDefault() {
+		field.Default = fmt.Sprint(v.Default)
+	}
+	return field
+}
+
+// applyRequiredVarDefaults sets the default of each missing required var of t
+// in the vars of call. Returns true if any default was set (caller should
+// recompile the task).
+func applyRequiredVarDefaults(t *ast.Task, call *Call) bool {
+	var vars *ast.Vars
+	for _, v := range getMissingRequiredVars(t) {
+		if !v.HasDefault() {
+			continue
+		}
+		if vars == nil {
+			vars = call.Vars.DeepCopy()
+			if vars == nil {
+				vars = ast.NewVars()
+			}
+		}
+		vars.Set(v.Name, ast.Var{
+			Value:  v.Default,
+			Secret: v.Secret,
+			Origin: &ast.VarOrigin{Layer: ast.VarLayerDefault, Location: v.Location},
+		})
+	}
+	if vars == nil {
+		return false
+	}
+	call.Vars = vars
+	return true
+}
+
 // getMissingRequiredVars returns required vars that are not set in the task's vars.
 func getMissingRequiredVars(t *ast.Task) []*ast.VarsWithValidation {
-	if t.Requires == nil {
-		return nil
-	}
 	var missing []*ast.VarsWithValidation
-	for _, v := range t.Requires.Vars {
+	for _, v := range t.RequiredVars() {
 		if _, ok := t.Vars.Get(v.Name); !ok {
 			missing = append(missing, v)
 		}
@@ -182,13 +231,31 @@ func (e *Executor) areTaskRequiredVarsSet(t *ast.Task) error {
 }
 
 func (e *Executor) areTaskRequiredVarsAllowedValuesSet(t *ast.Task) error {
-	if t.Requires == nil || len(t.Requires.Vars) == 0 {
-		return nil
-	}
+	var (
+		notAllowedValuesVars []errors.NotAllowedVar
+		invalidVars          []errors.InvalidVar
+	)
+	for _, requiredVar := range t.RequiredVars() {
+		varValue, ok := t.Vars.Get(requiredVar.Name)
+		if !ok {
+			continue
+		}
 
-	var notAllowedValuesVars []errors.NotAllowedVar
-	for _, requiredVar := range t.Requires.Vars {
-		varValue, _ := t.Vars.Get(requiredVar.Name)
+		if err := requiredVar.Validate(varValue.Value, t.Dir); err != nil {
+			value := fmt.Sprint(varValue.Value)
+			if varValue.Secret || requiredVar.Secret {
+				value = "*****"
+			}
+			location, snippet := requiredVarLocation(requiredVar)
+			invalidVars = append(invalidVars, errors.InvalidVar{
+				Name:     requiredVar.Name,
+				Value:    value,
+				Reason:   err.Error(),
+				Location: location,
+				Snippet:  snippet,
+			})
+			continue
+		}
 
 		enumValues := getEnumValues(requiredVar.Enum)
 		value, isString := varValue.Value.(string)
@@ -208,9 +275,38 @@ func (e *Executor) areTaskRequiredVarsAllowedValuesSet(t *ast.Task) error {
 		}
 	}
 
+	if len(invalidVars) > 0 {
+		return &errors.TaskInvalidVarsError{
+			TaskName:    t.Name(),
+			InvalidVars: invalidVars,
+		}
+	}
+
 	return nil
 }
 
+// requiredVarLocation returns the position where v is required and, for local
+// Taskfiles, a snippet of the Taskfile around it.
+func requiredVarLocation(v *ast.VarsWithValidation) (string, string) {
+	if v.Location == nil || v.Location.Taskfile == "" {
+		return "", ""
+	}
+	location := fmt.Sprintf("%s:%d:%d", filepathext.TryAbsToRel(v.Location.Taskfile), v.Location.Line, v.Location.Column)
+	if taskfile.IsRemoteEntrypoint(v.Location.Taskfile) {
+		return location, ""
+	}
+	b, err := os.ReadFile(v.Location.Taskfile)
+	if err != nil {
+		return location, ""
+	}
+	snippet := taskfile.NewSnippet(b,
+		taskfile.WithLine(v.Location.Line),
+		taskfile.WithColumn(v.Location.Column),
+		taskfile.WithPadding(1),
+	)
+	return location, snippet.String()
+}
+
 func getEnumValues(e *ast.Enum) []string {
 	if e == nil {
 		return nil
@@ -227,6 +323,6 @@ func resolveEnumRefForPrompt(v *ast.VarsWithValidation, vars *ast.Vars) *ast.Var
 	}
 	vCopy := v.DeepCopy()
 	cache := &templater.Cache{Vars: vars}
-	_ = resolveEnumRefs(&ast.Requires{Vars: []*ast.VarsWithValidation{vCopy}}, cache)
+	_ = resolveEnumRefs([]*ast.VarsWithValidation{vCopy}, cache)
 	return vCopy
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/execext"
 	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/fingerprint"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/output"
 	"github.com/go-task/task/v3/internal/version"
@@ -25,6 +26,7 @@ import (
 
 func (e *Executor) Setup() error {
 	e.setupLogger()
+	e.setupNetrc()
 	node, err := e.getRootNode()
 	if err != nil {
 		return err
@@ -35,6 +37,9 @@ func (e *Executor) Setup() error {
 	if err := e.readTaskfile(node); err != nil {
 		return err
 	}
+	if err := e.checkReservedFlags(); err != nil {
+		return err
+	}
 	e.setupStdFiles()
 	if err := e.setupOutput(); err != nil {
 		return err
@@ -50,6 +55,8 @@ func (e *Executor) Setup() error {
 	}
 	e.setupDefaults()
 	e.setupConcurrencyState()
+	e.setupArtifactCache()
+	e.fingerprintCache = fingerprint.NewCache(e.TempDir.Fingerprint, e.Dry)
 	return nil
 }
 
@@ -58,6 +65,8 @@ func (e *Executor) getRootNode() (taskfile.Node, error) {
 		taskfile.WithCACert(e.CACert),
 		taskfile.WithCert(e.Cert),
 		taskfile.WithCertKey(e.CertKey),
+		taskfile.WithAuth(e.RemoteAuth),
+		taskfile.WithNetrc(e.netrc),
 	)
 	if taskNotFoundError, ok := errors.AsType[errors.TaskfileNotFoundError](err); ok {
 		taskNotFoundError.AskInit = true
@@ -80,18 +89,29 @@ func (e *Executor) readTaskfile(node taskfile.Node) error {
 	promptFunc := func(s string) error {
 		return e.Logger.Prompt(logger.Yellow, s, "n", "y", "yes")
 	}
+	lockfilePath := filepathext.SmartJoin(e.Dir, taskfile.LockfileName)
+	lockfile, err := taskfile.ReadLockfile(lockfilePath)
+	if err != nil {
+		return err
+	}
 	reader := taskfile.NewReader(
 		taskfile.WithInsecure(e.Insecure),
-		taskfile.WithDownload(e.Download),
+		taskfile.WithDownload(e.Download || e.UpdateLock),
+		taskfile.WithLockfile(lockfile),
+		taskfile.WithUpdateLock(e.UpdateLock),
 		taskfile.WithOffline(e.Offline),
 		taskfile.WithTrustedHosts(e.TrustedHosts),
+		taskfile.WithTrustedKeys(e.TrustedKeys),
 		taskfile.WithTempDir(e.TempDir.Remote),
 		taskfile.WithCacheExpiryDuration(e.CacheExpiryDuration),
 		taskfile.WithReaderCACert(e.CACert),
 		taskfile.WithReaderCert(e.Cert),
 		taskfile.WithReaderCertKey(e.CertKey),
+		taskfile.WithReaderAuth(e.RemoteAuth),
+		taskfile.WithReaderNetrc(e.netrc),
 		taskfile.WithDebugFunc(debugFunc),
 		taskfile.WithPromptFunc(promptFunc),
+		taskfile.WithDiffPromptFunc(e.promptRemoteDiff),
 	)
 	graph, err := reader.Read(ctx, node)
 	if err != nil {
@@ -103,6 +123,29 @@ func (e *Executor) readTaskfile(node taskfile.Node) error {
 	if e.Taskfile, err = graph.Merge(); err != nil {
 		return err
 	}
+	if e.UpdateLock {
+		return reader.Lockfile().Write(lockfilePath)
+	}
+	return nil
+}
+
+// checkReservedFlags rejects the args of tasks declaring a reserved flag, which
+// would never reach them.
+func (e *Executor) checkReservedFlags() error {
+	if len(e.ReservedFlags) == 0 {
+		return nil
+	}
+	for name, t := range e.Taskfile.Tasks.All(nil) {
+		for _, arg := range t.Args {
+			if !arg.IsFlag() || !slices.Contains(e.ReservedFlags, arg.Flag) {
+				continue
+			}
+			return errors.TaskfileInvalidError{
+				URI: filepathext.TryAbsToRel(t.Location.Taskfile),
+				Err: fmt.Errorf("invalid flag %q for argument %q of task %q: --%s is a flag of Task itself", arg.Flag, arg.Name, name, arg.Flag),
+			}
+		}
+	}
 	return nil
 }
 
@@ -181,6 +224,17 @@ func (e *Executor) setupStdFiles() {
 	}
 }
 
+// setupNetrc reads the netrc file of the user once for all the remote
+// Taskfiles. A malformed one is only reported, since most hosts don't need its
+// credentials.
+func (e *Executor) setupNetrc() {
+	netrc, err := taskfile.ReadNetrc()
+	if err != nil {
+		e.Logger.Warnf("%v\n", err)
+	}
+	e.netrc = netrc
+}
+
 func (e *Executor) setupLogger() {
 	e.Logger = &logger.Logger{
 		Stdin:      e.Stdin,
@@ -251,8 +305,8 @@ func (e *Executor) readDotEnvFiles() error {
 }
 
 func (e *Executor) setupDefaults() {
-	if e.Taskfile.Method == "" {
-		e.Taskfile.Method = "checksum"
+	if e.Taskfile.Method.IsZero() {
+		e.Taskfile.Method = ast.Method{Name: "checksum"}
 	}
 	if e.Taskfile.Run == "" {
 		e.Taskfile.Run = "always"
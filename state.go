@@ -0,0 +1,117 @@
+package task
+
+import (
+	"strings"
+
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/fingerprint"
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// PruneState removes the fingerprint states of the tasks that no longer exist
+// in the Taskfile, like renamed or removed tasks, and the stale states of the
+// existing ones, which Task can no longer look up. The entries of the index
+// left without a state and the cached hashes of deleted files are removed too.
+// With Dry, they are only listed.
+func (e *Executor) PruneState() error {
+	states, err := fingerprint.ReadStates(e.TempDir.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	var orphans []*fingerprint.State
+	for _, state := range states {
+		if state.Stale || !e.stateHasTask(state) {
+			orphans = append(orphans, state)
+		}
+	}
+	if err := e.removeStates(orphans); err != nil {
+		return err
+	}
+
+	keys, err := fingerprint.PruneStateIndex(e.TempDir.Fingerprint, e.Dry)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		e.logPruned("index entry", key)
+	}
+
+	paths, err := fingerprint.PruneFileHashes(e.TempDir.Fingerprint, e.Dry)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		e.logPruned("file hash of", filepathext.TryAbsToRel(path))
+	}
+	return nil
+}
+
+func (e *Executor) logPruned(kind, name string) {
+	if e.Dry {
+		e.Logger.Outf(logger.Default, "Would remove %s %s\n", kind, name)
+	} else {
+		e.Logger.Outf(logger.Default, "Removed %s %s\n", kind, name)
+	}
+}
+
+// ResetState removes the fingerprint states of every call variant of the task
+// called name, so it runs again on its next call. With Dry, the states are
+// only listed.
+func (e *Executor) ResetState(name string) error {
+	t, err := e.GetTask(&Call{Task: name})
+	if err != nil {
+		return err
+	}
+	// Calls matching a wildcard keep the name they were called with
+	if !strings.Contains(t.Task, "*") {
+		name = t.Task
+	}
+
+	states, err := fingerprint.ReadStates(e.TempDir.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	var matching []*fingerprint.State
+	for _, state := range states {
+		if state.MatchesName(name) {
+			matching = append(matching, state)
+		}
+	}
+	if len(matching) == 0 {
+		e.Logger.Errf(logger.Yellow, "task: No fingerprint found for task %q\n", name)
+		return nil
+	}
+	return e.removeStates(matching)
+}
+
+func (e *Executor) stateHasTask(state *fingerprint.State) bool {
+	for t := range e.Taskfile.Tasks.Values(nil) {
+		if state.MatchesTask(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Executor) removeStates(states []*fingerprint.State) error {
+	if !e.Dry {
+		if err := fingerprint.RemoveStates(e.TempDir.Fingerprint, states); err != nil {
+			return err
+		}
+	}
+	for _, state := range states {
+		name := state.Task
+		if name == "" {
+			name = "unknown task"
+		}
+		path := filepathext.TryAbsToRel(state.Path)
+		if e.Dry {
+			e.Logger.Outf(logger.Default, "Would remove %s state %s (%s)\n", state.Kind, path, name)
+		} else {
+			e.Logger.Outf(logger.Default, "Removed %s state %s (%s)\n", state.Kind, path, name)
+		}
+	}
+	return nil
+}
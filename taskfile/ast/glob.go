@@ -9,6 +9,9 @@ import (
 type Glob struct {
 	Glob   string
 	Negate bool
+	// Task is the name of a task whose generates are used in place of this
+	// glob. It is only allowed in sources and is resolved on compilation.
+	Task string
 }
 
 func (g *Glob) UnmarshalYAML(node *yaml.Node) error {
@@ -21,13 +24,23 @@ func (g *Glob) UnmarshalYAML(node *yaml.Node) error {
 	case yaml.MappingNode:
 		var glob struct {
 			Exclude string
+			Task    string
 		}
 		if err := node.Decode(&glob); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
-		g.Glob = glob.Exclude
-		g.Negate = true
-		return nil
+		switch {
+		case glob.Task != "" && glob.Exclude != "":
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("glob cannot have both exclude and task")
+		case glob.Task != "":
+			g.Task = glob.Task
+			return nil
+		case glob.Exclude != "":
+			g.Glob = glob.Exclude
+			g.Negate = true
+			return nil
+		}
+		return errors.NewTaskfileDecodeError(nil, node).WithMessage("glob must have either exclude or task")
 	}
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("glob")
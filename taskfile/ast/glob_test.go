@@ -0,0 +1,46 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestGlobParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content     string
+		expected    *ast.Glob
+		expectedErr string
+	}{
+		{content: "src/*.go", expected: &ast.Glob{Glob: "src/*.go"}},
+		{content: "{exclude: src/*_test.go}", expected: &ast.Glob{Glob: "src/*_test.go", Negate: true}},
+		{content: "{task: codegen}", expected: &ast.Glob{Task: "codegen"}},
+		{content: "{task: codegen, exclude: src/*.go}", expectedErr: "glob cannot have both exclude and task"},
+		{content: "{include: src/*.go}", expectedErr: "glob must have either exclude or task"},
+		{content: "[src/*.go]", expectedErr: "cannot unmarshal"},
+	}
+	for _, test := range tests {
+		var g ast.Glob
+		err := yaml.Unmarshal([]byte(test.content), &g)
+		if test.expectedErr != "" {
+			assert.ErrorContains(t, err, test.expectedErr, test.content)
+			continue
+		}
+		require.NoError(t, err, test.content)
+		assert.Equal(t, test.expected, &g, test.content)
+	}
+}
+
+func TestGeneratesTaskGlob(t *testing.T) {
+	t.Parallel()
+
+	var task ast.Task
+	err := yaml.Unmarshal([]byte("{generates: [{task: codegen}]}"), &task)
+	assert.ErrorContains(t, err, `generates cannot reference task "codegen", only sources can`)
+}
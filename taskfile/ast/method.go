@@ -0,0 +1,62 @@
+package ast
+
+import (
+	"slices"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// BuiltinMethods can't be shadowed by the methods declared in a Taskfile.
+var BuiltinMethods = []string{"timestamp", "checksum", "git", "none"}
+
+// Method is the method used to check whether a task is up-to-date. It is
+// either the name of a built-in method or of one declared in the methods of the
+// Taskfile, or a custom method running a command that prints a fingerprint.
+type Method struct {
+	Name string
+	Sh   string
+}
+
+func (m *Method) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+
+	case yaml.ScalarNode:
+		m.Name = node.Value
+		return nil
+
+	case yaml.MappingNode:
+		var method struct {
+			Sh string
+		}
+		if err := node.Decode(&method); err != nil {
+			return errors.NewTaskfileDecodeError(err, node)
+		}
+		if method.Sh == "" {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage(`a method must define "sh" or be a scalar value`)
+		}
+		m.Sh = method.Sh
+		return nil
+	}
+
+	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("method")
+}
+
+// IsNamed reports whether the method refers by its name to one declared in the
+// methods of a Taskfile.
+func (m Method) IsNamed() bool {
+	return m.Name != "" && m.Sh == "" && !slices.Contains(BuiltinMethods, m.Name)
+}
+
+// IsZero reports whether no method is set.
+func (m Method) IsZero() bool {
+	return m.Name == "" && m.Sh == ""
+}
+
+func (m Method) String() string {
+	if m.Sh != "" {
+		return "sh: " + m.Sh
+	}
+	return m.Name
+}
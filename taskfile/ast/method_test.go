@@ -0,0 +1,73 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestMethodParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content  string
+		expected ast.Method
+	}{
+		{
+			"checksum",
+			ast.Method{Name: "checksum"},
+		},
+		{
+			"docker",
+			ast.Method{Name: "docker"},
+		},
+		{
+			"sh: docker image inspect --format '{{.Id}}' app",
+			ast.Method{Sh: "docker image inspect --format '{{.Id}}' app"},
+		},
+	}
+	for _, test := range tests {
+		var method ast.Method
+		require.NoError(t, yaml.Unmarshal([]byte(test.content), &method))
+		assert.Equal(t, test.expected, method)
+	}
+
+	var method ast.Method
+	assert.Error(t, yaml.Unmarshal([]byte("cmd: echo"), &method))
+}
+
+func TestMethodsMerge(t *testing.T) {
+	t.Parallel()
+
+	parse := func(content string) *ast.Taskfile {
+		t.Helper()
+		var tf ast.Taskfile
+		require.NoError(t, yaml.Unmarshal([]byte(content), &tf))
+		return &tf
+	}
+	const included = `
+version: '3'
+methods:
+  version:
+    sh: echo lib
+tasks:
+  build:
+    method: version
+`
+
+	root := parse("version: '3'\nmethods:\n  version:\n    sh: echo root\n")
+	require.NoError(t, root.Merge(parse(included), &ast.Include{Namespace: "lib"}))
+	assert.Equal(t, "echo root", root.Methods["version"].Sh)
+	assert.Equal(t, "echo lib", root.Methods["lib:version"].Sh)
+	task, ok := root.Tasks.Get("lib:build")
+	require.True(t, ok)
+	assert.Equal(t, "lib:version", task.Method.Name)
+
+	// Flattened methods can't be declared twice
+	root = parse("version: '3'\nmethods:\n  version:\n    sh: echo root\n")
+	assert.Error(t, root.Merge(parse(included), &ast.Include{Namespace: "lib", Flatten: true}))
+}
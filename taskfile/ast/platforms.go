@@ -26,6 +26,15 @@ func (p *Platform) DeepCopy() *Platform {
 	}
 }
 
+// String returns the platform as written in the Taskfile, e.g. "linux/amd64",
+// "windows" or "arm64".
+func (p *Platform) String() string {
+	if p.OS != "" && p.Arch != "" {
+		return p.OS + "/" + p.Arch
+	}
+	return p.OS + p.Arch
+}
+
 type ErrInvalidPlatform struct {
 	Platform string
 }
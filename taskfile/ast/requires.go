@@ -1,10 +1,21 @@
 package ast
 
 import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"go.yaml.in/yaml/v3"
 
 	"github.com/go-task/task/v3/errors"
 	"github.com/go-task/task/v3/internal/deepcopy"
+	"github.com/go-task/task/v3/internal/filepathext"
 )
 
 // Requires represents a set of required variables necessary for a task to run
@@ -69,9 +80,47 @@ func (e *Enum) UnmarshalYAML(node *yaml.Node) error {
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("enum")
 }
 
+// The types a required variable can be validated against.
+const (
+	RequiredVarTypeString = "string"
+	RequiredVarTypeInt    = "int"
+	RequiredVarTypeBool   = "bool"
+	RequiredVarTypePath   = "path"
+	RequiredVarTypeURL    = "url"
+	RequiredVarTypeList   = "list"
+)
+
+var requiredVarTypes = []string{
+	RequiredVarTypeString,
+	RequiredVarTypeInt,
+	RequiredVarTypeBool,
+	RequiredVarTypePath,
+	RequiredVarTypeURL,
+	RequiredVarTypeList,
+}
+
 type VarsWithValidation struct {
 	Name string
 	Enum *Enum
+	// Type is one of the RequiredVarType constants. Empty accepts any value.
+	Type string
+	// Pattern is a regular expression the value, or each item of a list, must
+	// match.
+	Pattern string
+	pattern *regexp.Regexp
+	// Min and Max bound the value of an int, the number of items of a list or
+	// the length of any other value.
+	Min    *float64
+	Max    *float64
+	Desc   string
+	Secret bool
+	// Default is used when the variable is not set and can't be prompted for
+	// or when the prompt is left empty. Nil means no default.
+	Default any
+	// Example is an example value shown in the prompt and the summary
+	Example string
+	// Location is where the variable is required in the Taskfile
+	Location *Location
 }
 
 func (v *VarsWithValidation) DeepCopy() *VarsWithValidation {
@@ -79,13 +128,28 @@ func (v *VarsWithValidation) DeepCopy() *VarsWithValidation {
 		return nil
 	}
 	return &VarsWithValidation{
-		Name: v.Name,
-		Enum: v.Enum.DeepCopy(),
+		Name:     v.Name,
+		Enum:     v.Enum.DeepCopy(),
+		Type:     v.Type,
+		Pattern:  v.Pattern,
+		pattern:  v.pattern,
+		Min:      deepcopy.Scalar(v.Min),
+		Max:      deepcopy.Scalar(v.Max),
+		Desc:     v.Desc,
+		Secret:   v.Secret,
+		Default:  v.Default,
+		Example:  v.Example,
+		Location: v.Location.DeepCopy(),
 	}
 }
 
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (v *VarsWithValidation) UnmarshalYAML(node *yaml.Node) error {
+	v.Location = &Location{
+		Line:   node.Line,
+		Column: node.Column,
+	}
+
 	switch node.Kind {
 
 	case yaml.ScalarNode:
@@ -99,16 +163,166 @@ func (v *VarsWithValidation) UnmarshalYAML(node *yaml.Node) error {
 
 	case yaml.MappingNode:
 		var vv struct {
-			Name string
-			Enum *Enum
+			Name    string
+			Enum    *Enum
+			Type    string
+			Pattern string
+			Min     *float64
+			Max     *float64
+			Desc    string
+			Secret  bool
+			Default any
+			Example string
 		}
 		if err := node.Decode(&vv); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
 		}
+		if vv.Type != "" && !slices.Contains(requiredVarTypes, vv.Type) {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("%q is not a valid type for a required variable. Try one of %s", vv.Type, strings.Join(requiredVarTypes, ", "))
+		}
+		pattern, err := regexp.Compile(vv.Pattern)
+		if err != nil {
+			return errors.NewTaskfileDecodeError(err, node).WithMessage("invalid pattern for required variable %q: %v", vv.Name, err)
+		}
+		if vv.Min != nil && vv.Max != nil && *vv.Min > *vv.Max {
+			return errors.NewTaskfileDecodeError(nil, node).WithMessage("min of required variable %q is greater than its max", vv.Name)
+		}
 		v.Name = vv.Name
 		v.Enum = vv.Enum
+		v.Type = vv.Type
+		v.Pattern = vv.Pattern
+		v.pattern = pattern
+		v.Min = vv.Min
+		v.Max = vv.Max
+		v.Desc = vv.Desc
+		v.Secret = vv.Secret
+		v.Default = vv.Default
+		v.Example = vv.Example
 		return nil
 	}
 
 	return errors.NewTaskfileDecodeError(nil, node).WithTypeMessage("requires")
 }
+
+// HasDefault returns true if the variable declares a default value.
+func (v *VarsWithValidation) HasDefault() bool {
+	return v.Default != nil
+}
+
+// Validate checks value against the type, pattern and bounds of the variable
+// and returns an error describing the first mismatch. Relative paths are
+// resolved from dir.
+func (v *VarsWithValidation) Validate(value any, dir string) error {
+	if v.Type == "" && v.Pattern == "" && v.Min == nil && v.Max == nil {
+		return nil
+	}
+	var (
+		items []string
+		size  float64
+	)
+	switch v.Type {
+	case RequiredVarTypeInt:
+		n, err := strconv.Atoi(fmt.Sprint(value))
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		items, size = []string{strconv.Itoa(n)}, float64(n)
+	case RequiredVarTypeBool:
+		if _, err := strconv.ParseBool(fmt.Sprint(value)); err != nil {
+			return errors.New("must be a boolean")
+		}
+		return v.matchPattern([]string{fmt.Sprint(value)})
+	case RequiredVarTypeList:
+		items = listItems(value)
+		size = float64(len(items))
+	default:
+		s, ok := scalarString(value)
+		if !ok {
+			return fmt.Errorf("must be a %s", cmp.Or(v.Type, "scalar value"))
+		}
+		switch v.Type {
+		case RequiredVarTypePath:
+			if _, err := os.Stat(filepathext.SmartJoin(dir, s)); err != nil {
+				return errors.New("must be an existing path")
+			}
+		case RequiredVarTypeURL:
+			if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+				return errors.New("must be a URL with a scheme and a host")
+			}
+		}
+		items, size = []string{s}, float64(utf8.RuneCountInString(s))
+	}
+
+	if v.Min != nil && size < *v.Min {
+		return fmt.Errorf("%s must be at least %v", v.sizeName(), *v.Min)
+	}
+	if v.Max != nil && size > *v.Max {
+		return fmt.Errorf("%s must be at most %v", v.sizeName(), *v.Max)
+	}
+	return v.matchPattern(items)
+}
+
+func (v *VarsWithValidation) matchPattern(items []string) error {
+	if v.Pattern == "" {
+		return nil
+	}
+	// The pattern is compiled when decoded, unless the variable was built in code
+	re := v.pattern
+	if re == nil || re.String() != v.Pattern {
+		var err error
+		if re, err = regexp.Compile(v.Pattern); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if !re.MatchString(item) {
+			return fmt.Errorf("%q must match %s", item, v.Pattern)
+		}
+	}
+	return nil
+}
+
+func (v *VarsWithValidation) sizeName() string {
+	switch v.Type {
+	case RequiredVarTypeInt:
+		return "value"
+	case RequiredVarTypeList:
+		return "number of items"
+	default:
+		return "length"
+	}
+}
+
+// listItems returns the items of a list, or of a string of comma-separated
+// items as given on the command line.
+func listItems(value any) []string {
+	switch value := value.(type) {
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = fmt.Sprint(item)
+		}
+		return items
+	case []string:
+		return value
+	case string:
+		var items []string
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
+
+func scalarString(value any) (string, bool) {
+	switch value.(type) {
+	case map[string]any, []any, []string:
+		return "", false
+	default:
+		return fmt.Sprint(value), true
+	}
+}
@@ -0,0 +1,68 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestVarsWithValidationParse(t *testing.T) {
+	t.Parallel()
+
+	var v ast.VarsWithValidation
+	require.NoError(t, yaml.Unmarshal([]byte("{name: PORT, type: int, min: 1, max: 65535, desc: The port}"), &v))
+	assert.Equal(t, "int", v.Type)
+	assert.Equal(t, 1.0, *v.Min)
+	assert.Equal(t, 65535.0, *v.Max)
+	assert.Equal(t, "The port", v.Desc)
+	assert.Equal(t, &ast.Location{Line: 1, Column: 1}, v.Location)
+
+	assert.ErrorContains(t, yaml.Unmarshal([]byte("{name: PORT, type: number}"), &v), `"number" is not a valid type`)
+	assert.ErrorContains(t, yaml.Unmarshal([]byte("{name: PORT, pattern: '['}"), &v), "invalid pattern")
+	assert.ErrorContains(t, yaml.Unmarshal([]byte("{name: PORT, min: 2, max: 1}"), &v), "greater than its max")
+}
+
+func TestVarsWithValidationValidate(t *testing.T) {
+	t.Parallel()
+
+	float := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name  string
+		v     ast.VarsWithValidation
+		value any
+		err   string
+	}{
+		{"untyped", ast.VarsWithValidation{}, map[string]any{}, ""},
+		{"int", ast.VarsWithValidation{Type: "int", Min: float(1)}, "3", ""},
+		{"int invalid", ast.VarsWithValidation{Type: "int"}, "three", "must be an integer"},
+		{"int too small", ast.VarsWithValidation{Type: "int", Min: float(1)}, 0, "value must be at least 1"},
+		{"bool", ast.VarsWithValidation{Type: "bool"}, true, ""},
+		{"bool invalid", ast.VarsWithValidation{Type: "bool"}, "yes", "must be a boolean"},
+		{"string too long", ast.VarsWithValidation{Max: float(3)}, "abcd", "length must be at most 3"},
+		{"string map", ast.VarsWithValidation{Type: "string"}, map[string]any{}, "must be a string"},
+		{"pattern", ast.VarsWithValidation{Pattern: `^v\d+$`}, "v1", ""},
+		{"pattern mismatch", ast.VarsWithValidation{Pattern: `^v\d+$`}, "1", `"1" must match`},
+		{"url", ast.VarsWithValidation{Type: "url"}, "https://taskfile.dev", ""},
+		{"url without scheme", ast.VarsWithValidation{Type: "url"}, "taskfile.dev", "must be a URL"},
+		{"path", ast.VarsWithValidation{Type: "path"}, "requires_test.go", ""},
+		{"path missing", ast.VarsWithValidation{Type: "path"}, "missing.go", "must be an existing path"},
+		{"list", ast.VarsWithValidation{Type: "list", Max: float(2), Pattern: `^[a-z]+$`}, []any{"eu", "us"}, ""},
+		{"list from string", ast.VarsWithValidation{Type: "list", Min: float(3)}, "eu, us", "number of items must be at least 3"},
+		{"list item mismatch", ast.VarsWithValidation{Type: "list", Pattern: `^[a-z]+$`}, "eu,US", `"US" must match`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.v.Validate(test.value, ".")
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ type Task struct {
 	Prompt        Prompt
 	Summary       string
 	Requires      *Requires
+	Args          []*Arg
 	Aliases       []string
 	Sources       []*Glob
 	Generates     []*Glob
@@ -35,20 +36,34 @@ type Task struct {
 	Silent        *bool
 	Interactive   bool
 	Internal      bool
-	Method        string
+	Method        Method
 	Prefix        string `hash:"ignore"`
 	IgnoreError   bool
 	UseGitignore  *bool
+	// FingerprintTask folds the compiled cmds, vars, env and dir of the task
+	// into its checksum, on top of its sources.
+	FingerprintTask *bool
+	// VerifyGenerates records the hashes of the generated files after each
+	// successful run and considers the task stale if they diverge.
+	VerifyGenerates *bool
+	// DependsOnDeps considers the task stale whenever one of its deps ran
+	// instead of being up to date.
+	DependsOnDeps *bool
 	Run           string
 	Platforms     []*Platform
 	If            string
 	Watch         bool
 	Location      *Location
 	Failfast      bool
+	// Populated during reading
+	TaskfileDir string `hash:"ignore"`
 	// Populated during merging
 	Namespace            string `hash:"ignore"`
 	IncludeVars          *Vars
 	IncludedTaskfileVars *Vars
+	// Populated during compilation
+	CallVars          *Vars `hash:"ignore"`
+	UsesSourceChanges bool  `hash:"ignore"`
 
 	FullName string `hash:"ignore"`
 }
@@ -82,6 +97,38 @@ func (t *Task) ShouldUseGitignore() bool {
 	return t.UseGitignore != nil && *t.UseGitignore
 }
 
+// ShouldFingerprintTask returns true if the definition of the task is part of
+// its checksum. Returns false if FingerprintTask is nil or set to false.
+func (t *Task) ShouldFingerprintTask() bool {
+	return t.FingerprintTask != nil && *t.FingerprintTask
+}
+
+// ShouldVerifyGenerates returns true if the generated files of the task are
+// checked against the manifest recorded after its last successful run.
+// Returns false if VerifyGenerates is nil or set to false.
+func (t *Task) ShouldVerifyGenerates() bool {
+	return t.VerifyGenerates != nil && *t.VerifyGenerates
+}
+
+// ShouldDependOnDeps returns true if the task must run whenever one of its
+// deps ran. Returns false if DependsOnDeps is nil or set to false.
+func (t *Task) ShouldDependOnDeps() bool {
+	return t.DependsOnDeps != nil && *t.DependsOnDeps
+}
+
+// RequiredVars returns the variables the task requires: the ones listed in
+// requires followed by its args.
+func (t *Task) RequiredVars() []*VarsWithValidation {
+	var vars []*VarsWithValidation
+	if t.Requires != nil {
+		vars = append(vars, t.Requires.Vars...)
+	}
+	for _, arg := range t.Args {
+		vars = append(vars, &arg.VarsWithValidation)
+	}
+	return vars
+}
+
 // WildcardMatch will check if the given string matches the name of the Task and returns any wildcard values.
 func (t *Task) WildcardMatch(name string) (bool, []string) {
 	names := append([]string{t.Task}, t.Aliases...)
@@ -133,37 +180,41 @@ func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 	// Full task object
 	case yaml.MappingNode:
 		var task struct {
-			Cmds          []*Cmd
-			Cmd           *Cmd
-			Deps          []*Dep
-			Label         string
-			Desc          string
-			Prompt        Prompt
-			Summary       string
-			Aliases       []string
-			Sources       []*Glob
-			Generates     []*Glob
-			Status        []string
-			Preconditions []*Precondition
-			Dir           string
-			Set           []string
-			Shopt         []string
-			Vars          *Vars
-			Env           *Vars
-			Dotenv        []string
-			Silent        *bool `yaml:"silent,omitempty"`
-			Interactive   bool
-			Internal      bool
-			Method        string
-			Prefix        string
-			IgnoreError   bool  `yaml:"ignore_error"`
-			UseGitignore  *bool `yaml:"use_gitignore,omitempty"`
-			Run           string
-			Platforms     []*Platform
-			If            string
-			Requires      *Requires
-			Watch         bool
-			Failfast      bool
+			Cmds            []*Cmd
+			Cmd             *Cmd
+			Deps            []*Dep
+			Label           string
+			Desc            string
+			Prompt          Prompt
+			Summary         string
+			Aliases         []string
+			Sources         []*Glob
+			Generates       []*Glob
+			Status          []string
+			Preconditions   []*Precondition
+			Dir             string
+			Set             []string
+			Shopt           []string
+			Vars            *Vars
+			Env             *Vars
+			Dotenv          []string
+			Silent          *bool `yaml:"silent,omitempty"`
+			Interactive     bool
+			Internal        bool
+			Method          Method
+			Prefix          string
+			IgnoreError     bool  `yaml:"ignore_error"`
+			UseGitignore    *bool `yaml:"use_gitignore,omitempty"`
+			FingerprintTask *bool `yaml:"fingerprint_task,omitempty"`
+			VerifyGenerates *bool `yaml:"verify_generates,omitempty"`
+			DependsOnDeps   *bool `yaml:"depends_on_deps,omitempty"`
+			Run             string
+			Platforms       []*Platform
+			If              string
+			Requires        *Requires
+			Args            []*Arg
+			Watch           bool
+			Failfast        bool
 		}
 		if err := node.Decode(&task); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
@@ -176,6 +227,11 @@ func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 		} else {
 			t.Cmds = task.Cmds
 		}
+		for _, g := range task.Generates {
+			if g != nil && g.Task != "" {
+				return errors.NewTaskfileDecodeError(nil, node).WithMessage("generates cannot reference task %q, only sources can", g.Task)
+			}
+		}
 		t.Deps = task.Deps
 		t.Label = task.Label
 		t.Desc = task.Desc
@@ -199,10 +255,14 @@ func (t *Task) UnmarshalYAML(node *yaml.Node) error {
 		t.Prefix = task.Prefix
 		t.IgnoreError = task.IgnoreError
 		t.UseGitignore = deepcopy.Scalar(task.UseGitignore)
+		t.FingerprintTask = deepcopy.Scalar(task.FingerprintTask)
+		t.VerifyGenerates = deepcopy.Scalar(task.VerifyGenerates)
+		t.DependsOnDeps = deepcopy.Scalar(task.DependsOnDeps)
 		t.Run = task.Run
 		t.Platforms = task.Platforms
 		t.If = task.If
 		t.Requires = task.Requires
+		t.Args = task.Args
 		t.Watch = task.Watch
 		t.Failfast = task.Failfast
 		return nil
@@ -243,13 +303,20 @@ func (t *Task) DeepCopy() *Task {
 		Prefix:               t.Prefix,
 		IgnoreError:          t.IgnoreError,
 		UseGitignore:         deepcopy.Scalar(t.UseGitignore),
+		FingerprintTask:      deepcopy.Scalar(t.FingerprintTask),
+		VerifyGenerates:      deepcopy.Scalar(t.VerifyGenerates),
+		DependsOnDeps:        deepcopy.Scalar(t.DependsOnDeps),
 		Run:                  t.Run,
 		IncludeVars:          t.IncludeVars.DeepCopy(),
 		IncludedTaskfileVars: t.IncludedTaskfileVars.DeepCopy(),
+		CallVars:             t.CallVars.DeepCopy(),
+		UsesSourceChanges:    t.UsesSourceChanges,
 		Platforms:            deepcopy.Slice(t.Platforms),
 		If:                   t.If,
 		Location:             t.Location.DeepCopy(),
+		TaskfileDir:          t.TaskfileDir,
 		Requires:             t.Requires.DeepCopy(),
+		Args:                 deepcopy.Slice(t.Args),
 		Namespace:            t.Namespace,
 		FullName:             t.FullName,
 		Watch:                t.Watch,
@@ -20,21 +20,25 @@ var ErrIncludedTaskfilesCantHaveDotenvs = errors.New("task: Included Taskfiles c
 
 // Taskfile is the abstract syntax tree for a Taskfile
 type Taskfile struct {
-	Location     string
-	Version      *semver.Version
-	Output       Output
-	Method       string
-	Includes     *Includes
-	Set          []string
-	Shopt        []string
-	Vars         *Vars
-	Env          *Vars
-	Tasks        *Tasks
-	Silent       bool
-	Dotenv       []string
-	Run          string
-	Interval     time.Duration
-	UseGitignore *bool
+	Location        string
+	Version         *semver.Version
+	Output          Output
+	Method          Method
+	Methods         map[string]*Method
+	Includes        *Includes
+	Set             []string
+	Shopt           []string
+	Vars            *Vars
+	Env             *Vars
+	Tasks           *Tasks
+	Silent          bool
+	Dotenv          []string
+	Run             string
+	Interval        time.Duration
+	UseGitignore    *bool
+	FingerprintTask *bool
+	VerifyGenerates *bool
+	DependsOnDeps   *bool
 }
 
 // Merge merges the second Taskfile into the first
@@ -60,6 +64,23 @@ func (t1 *Taskfile) Merge(t2 *Taskfile, include *Include) error {
 	if t1.Tasks == nil {
 		t1.Tasks = NewTasks()
 	}
+	// Like tasks, the methods of an included Taskfile are namespaced
+	for name, method := range t2.Methods {
+		if !(Method{Name: name}).IsNamed() {
+			continue
+		}
+		methodName := name
+		if !include.Flatten {
+			methodName = taskNameWithNamespace(name, include.Namespace)
+		}
+		if _, ok := t1.Methods[methodName]; ok {
+			return fmt.Errorf("task: method %q of included Taskfile %q is already defined", methodName, include.Namespace)
+		}
+		if t1.Methods == nil {
+			t1.Methods = map[string]*Method{}
+		}
+		t1.Methods[methodName] = method
+	}
 	if t2.Silent {
 		for _, t := range t2.Tasks.All(nil) {
 			if t.Silent == nil {
@@ -76,6 +97,30 @@ func (t1 *Taskfile) Merge(t2 *Taskfile, include *Include) error {
 			}
 		}
 	}
+	if t2.FingerprintTask != nil {
+		for _, t := range t2.Tasks.All(nil) {
+			if t.FingerprintTask == nil {
+				v := *t2.FingerprintTask
+				t.FingerprintTask = &v
+			}
+		}
+	}
+	if t2.VerifyGenerates != nil {
+		for _, t := range t2.Tasks.All(nil) {
+			if t.VerifyGenerates == nil {
+				v := *t2.VerifyGenerates
+				t.VerifyGenerates = &v
+			}
+		}
+	}
+	if t2.DependsOnDeps != nil {
+		for _, t := range t2.Tasks.All(nil) {
+			if t.DependsOnDeps == nil {
+				v := *t2.DependsOnDeps
+				t.DependsOnDeps = &v
+			}
+		}
+	}
 	t1.Vars.Merge(t2.Vars, include)
 	t1.Env.Merge(t2.Env, include)
 	return t1.Tasks.Merge(t2.Tasks, include, t1.Vars)
@@ -85,20 +130,24 @@ func (tf *Taskfile) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 	case yaml.MappingNode:
 		var taskfile struct {
-			Version      *semver.Version
-			Output       Output
-			Method       string
-			Includes     *Includes
-			Set          []string
-			Shopt        []string
-			Vars         *Vars
-			Env          *Vars
-			Tasks        *Tasks
-			Silent       bool
-			Dotenv       []string
-			Run          string
-			Interval     time.Duration
-			UseGitignore *bool `yaml:"use_gitignore"`
+			Version         *semver.Version
+			Output          Output
+			Method          Method
+			Methods         map[string]*Method
+			Includes        *Includes
+			Set             []string
+			Shopt           []string
+			Vars            *Vars
+			Env             *Vars
+			Tasks           *Tasks
+			Silent          bool
+			Dotenv          []string
+			Run             string
+			Interval        time.Duration
+			UseGitignore    *bool `yaml:"use_gitignore"`
+			FingerprintTask *bool `yaml:"fingerprint_task"`
+			VerifyGenerates *bool `yaml:"verify_generates"`
+			DependsOnDeps   *bool `yaml:"depends_on_deps"`
 		}
 		if err := node.Decode(&taskfile); err != nil {
 			return errors.NewTaskfileDecodeError(err, node)
@@ -106,6 +155,7 @@ func (tf *Taskfile) UnmarshalYAML(node *yaml.Node) error {
 		tf.Version = taskfile.Version
 		tf.Output = taskfile.Output
 		tf.Method = taskfile.Method
+		tf.Methods = taskfile.Methods
 		tf.Includes = taskfile.Includes
 		tf.Set = taskfile.Set
 		tf.Shopt = taskfile.Shopt
@@ -117,6 +167,9 @@ func (tf *Taskfile) UnmarshalYAML(node *yaml.Node) error {
 		tf.Run = taskfile.Run
 		tf.Interval = taskfile.Interval
 		tf.UseGitignore = taskfile.UseGitignore
+		tf.FingerprintTask = taskfile.FingerprintTask
+		tf.VerifyGenerates = taskfile.VerifyGenerates
+		tf.DependsOnDeps = taskfile.DependsOnDeps
 		if tf.Includes == nil {
 			tf.Includes = NewIncludes()
 		}
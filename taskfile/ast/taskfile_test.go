@@ -28,6 +28,9 @@ vars:
 		yamlDeferredCmd             = `defer: echo 'test'`
 		yamlDeferredCmdWithTimeout  = `{ defer: echo 'test', timeout: 1s }`
 	)
+	origin := func(line, column int) *ast.VarOrigin {
+		return &ast.VarOrigin{Location: &ast.Location{Line: line, Column: column}}
+	}
 	tests := []struct {
 		content  string
 		v        any
@@ -47,13 +50,15 @@ vars:
 					&ast.VarElement{
 						Key: "PARAM1",
 						Value: ast.Var{
-							Value: "VALUE1",
+							Value:  "VALUE1",
+							Origin: origin(4, 3),
 						},
 					},
 					&ast.VarElement{
 						Key: "PARAM2",
 						Value: ast.Var{
-							Value: "VALUE2",
+							Value:  "VALUE2",
+							Origin: origin(5, 3),
 						},
 					},
 				),
@@ -73,7 +78,8 @@ vars:
 					&ast.VarElement{
 						Key: "PARAM1",
 						Value: ast.Var{
-							Value: "var",
+							Value:  "var",
+							Origin: origin(1, 35),
 						},
 					},
 				),
@@ -104,13 +110,15 @@ vars:
 					&ast.VarElement{
 						Key: "PARAM1",
 						Value: ast.Var{
-							Value: "VALUE1",
+							Value:  "VALUE1",
+							Origin: origin(4, 3),
 						},
 					},
 					&ast.VarElement{
 						Key: "PARAM2",
 						Value: ast.Var{
-							Value: "VALUE2",
+							Value:  "VALUE2",
+							Origin: origin(5, 3),
 						},
 					},
 				),
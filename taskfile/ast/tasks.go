@@ -151,6 +151,18 @@ func (t1 *Tasks) Merge(t2 *Tasks, include *Include, includedTaskfileVars *Vars)
 				}
 			}
 
+			// Add namespaces to the tasks referenced by sources
+			for i, source := range task.Sources {
+				if source != nil && source.Task != "" {
+					task.Sources[i] = &Glob{Task: taskNameWithNamespace(source.Task, include.Namespace)}
+				}
+			}
+
+			// Add namespaces to the methods referenced by name
+			if task.Method.IsNamed() {
+				task.Method.Name = taskNameWithNamespace(task.Method.Name, include.Namespace)
+			}
+
 			// Add namespaces to task aliases
 			for i, alias := range task.Aliases {
 				task.Aliases[i] = taskNameWithNamespace(alias, include.Namespace)
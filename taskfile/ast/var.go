@@ -1,9 +1,28 @@
 package ast
 
 import (
+	"fmt"
+
 	"go.yaml.in/yaml/v3"
 
 	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/filepathext"
+)
+
+// The layers a variable can be set in, from the lowest to the highest
+// priority when a task is compiled.
+const (
+	VarLayerEnvironment      = "environment"
+	VarLayerSpecial          = "special"
+	VarLayerDefault          = "default"
+	VarLayerDotenv           = "dotenv"
+	VarLayerTaskfile         = "taskfile"
+	VarLayerCLI              = "cli"
+	VarLayerInclude          = "include"
+	VarLayerIncludedTaskfile = "included taskfile"
+	VarLayerCall             = "call"
+	VarLayerArg              = "arg"
+	VarLayerTask             = "task"
 )
 
 // Var represents either a static or dynamic variable.
@@ -14,6 +33,85 @@ type Var struct {
 	Ref    string
 	Dir    string
 	Secret bool
+	Origin *VarOrigin
+}
+
+// VarOrigin records where the value of a variable was set and the origin of
+// the value it overrode, if any. Origins are shared between copies of a
+// variable, so they must not be modified once set.
+type VarOrigin struct {
+	Layer     string
+	Location  *Location
+	Overrides *VarOrigin
+}
+
+// WithOrigin returns a copy of v whose origin is in layer, unless the layer of
+// its origin is already known, and overrides prev after the origins it already
+// overrides.
+func (v Var) WithOrigin(layer string, prev *VarOrigin) Var {
+	// A variable applied again in another layer doesn't override itself
+	if v.Origin != nil && prev != nil && v.Origin.Location != nil && v.Origin.Location == prev.Location {
+		return v.WithOrigin(prev.Layer, prev.Overrides)
+	}
+	origin := &VarOrigin{Layer: layer}
+	if v.Origin != nil {
+		*origin = *v.Origin
+		if origin.Layer == "" {
+			origin.Layer = layer
+		}
+	}
+	origin.Overrides = appendOrigin(origin.Overrides, prev)
+	v.Origin = origin
+	return v
+}
+
+// appendOrigin returns a copy of the chain of origins starting at o, followed
+// by prev.
+func appendOrigin(o, prev *VarOrigin) *VarOrigin {
+	if o == nil {
+		return prev
+	}
+	if prev == nil {
+		return o
+	}
+	c := *o
+	c.Overrides = appendOrigin(o.Overrides, prev)
+	return &c
+}
+
+// inLayer returns o, or a copy of o in layer if the layer of o is unknown.
+func (o *VarOrigin) inLayer(layer string) *VarOrigin {
+	if o == nil || o.Layer != "" {
+		return o
+	}
+	c := *o
+	c.Layer = layer
+	return &c
+}
+
+// String returns the layer of the origin followed by the file and line where
+// the variable was set, if known.
+func (o *VarOrigin) String() string {
+	if o == nil {
+		return ""
+	}
+	if o.Location == nil || o.Location.Taskfile == "" {
+		return o.Layer
+	}
+	if o.Location.Line == 0 {
+		return fmt.Sprintf("%s (%s)", o.Layer, filepathext.TryAbsToRel(o.Location.Taskfile))
+	}
+	return fmt.Sprintf("%s (%s:%d)", o.Layer, filepathext.TryAbsToRel(o.Location.Taskfile), o.Location.Line)
+}
+
+// Chain returns the origin followed by the origins it overrode, from the most
+// to the least recent.
+func (o *VarOrigin) Chain() []*VarOrigin {
+	var chain []*VarOrigin
+	for ; o != nil; o = o.Overrides {
+		chain = append(chain, o)
+	}
+	return chain
 }
 
 func (v *Var) UnmarshalYAML(node *yaml.Node) error {
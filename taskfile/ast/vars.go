@@ -121,7 +121,8 @@ func (vars *Vars) ToCacheMap() (m map[string]any) {
 
 // Merge loops over other and merges it values with the variables in vars. If
 // the include parameter is not nil and its it is an advanced import, the
-// directory is set to the value of the include parameter.
+// directory is set to the value of the include parameter. The origin of the
+// merged variables records the variables they override.
 func (vars *Vars) Merge(other *Vars, include *Include) {
 	if vars == nil || vars.om == nil || other == nil {
 		return
@@ -132,7 +133,24 @@ func (vars *Vars) Merge(other *Vars, include *Include) {
 		if include != nil && include.AdvancedImport {
 			pair.Value.Dir = include.Dir
 		}
-		vars.om.Set(pair.Key, pair.Value)
+		value := pair.Value
+		if prev, ok := vars.om.Get(pair.Key); ok && prev.Origin != nil {
+			value = value.WithOrigin("", prev.Origin.inLayer(VarLayerTaskfile))
+		}
+		if include != nil {
+			value = value.WithOrigin(VarLayerIncludedTaskfile, nil)
+		}
+		vars.om.Set(pair.Key, value)
+	}
+}
+
+// SetLocationTaskfile sets the Taskfile of the location of the variables
+// decoded from it.
+func (vars *Vars) SetLocationTaskfile(taskfile string) {
+	for v := range vars.Values() {
+		if v.Origin != nil && v.Origin.Location != nil && v.Origin.Location.Taskfile == "" {
+			v.Origin.Location.Taskfile = taskfile
+		}
 	}
 }
 
@@ -196,6 +214,14 @@ func (vs *Vars) UnmarshalYAML(node *yaml.Node) error {
 				return errors.NewTaskfileDecodeError(err, node)
 			}
 
+			// Record where the variable is set
+			v.Origin = &VarOrigin{
+				Location: &Location{
+					Line:   keyNode.Line,
+					Column: keyNode.Column,
+				},
+			}
+
 			// Add the task to the ordered map
 			vs.Set(keyNode.Value, v)
 		}
@@ -53,3 +53,28 @@ func TestVars_ToCacheMap(t *testing.T) {
 		assert.Equal(t, map[string]any{"STATIC": "ok"}, m)
 	})
 }
+
+func TestVars_MergeOrigin(t *testing.T) {
+	t.Parallel()
+
+	location := &Location{Taskfile: "Taskfile.yml", Line: 4}
+	vars := NewVars(
+		&VarElement{Key: "FOO", Value: Var{Value: "taskfile", Origin: &VarOrigin{Location: location}}},
+	)
+	cli := &VarOrigin{Layer: VarLayerCLI}
+	vars.Merge(NewVars(&VarElement{Key: "FOO", Value: Var{Value: "cli", Origin: cli}}), nil)
+
+	v, ok := vars.Get("FOO")
+	assert.True(t, ok)
+	assert.Equal(t, "cli", v.Value)
+	assert.Equal(t, []*VarOrigin{
+		{Layer: VarLayerCLI, Overrides: &VarOrigin{Layer: VarLayerTaskfile, Location: location}},
+		{Layer: VarLayerTaskfile, Location: location},
+	}, v.Origin.Chain())
+	assert.Nil(t, cli.Overrides, "merged origins must not be modified")
+
+	// Applying the same variable again doesn't override itself
+	v = Var{Value: "taskfile", Origin: &VarOrigin{Location: location}}.WithOrigin(VarLayerIncludedTaskfile, v.Origin.Overrides)
+	assert.Equal(t, "taskfile (Taskfile.yml:4)", v.Origin.String())
+	assert.Nil(t, v.Origin.Overrides)
+}
@@ -0,0 +1,124 @@
+package taskfile
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/bgentry/go-netrc/netrc"
+)
+
+// An HTTPAuth holds the credentials sent to a host serving remote Taskfiles.
+// A Token is sent as a bearer token, otherwise the Username and Password are
+// sent with basic authentication.
+type HTTPAuth struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// A Netrc holds the credentials of the user's netrc file. Its default entry is
+// ignored, as its credentials would be sent to any host.
+type Netrc struct {
+	netrc *netrc.Netrc
+}
+
+// authTransport adds the credentials of the host of each request, so they are
+// sent on the HEAD probes of [RemoteExists] as well as on the downloads, but
+// never to another host after a redirect.
+type authTransport struct {
+	base  http.RoundTripper
+	auth  map[string]*HTTPAuth
+	netrc *Netrc
+}
+
+// withAuth returns a client that authenticates its requests with the
+// credentials configured for their host or, failing that, with the ones of the
+// netrc file n, which may be nil. It returns client as is if there are no
+// credentials.
+func withAuth(client *http.Client, auth map[string]*HTTPAuth, n *Netrc) *http.Client {
+	if len(auth) == 0 && n == nil {
+		return client
+	}
+	authClient := *client
+	authClient.Transport = &authTransport{
+		base:  cmp.Or(client.Transport, http.DefaultTransport),
+		auth:  auth,
+		netrc: n,
+	}
+	return &authClient
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Credentials in the URL itself take precedence
+	if req.Header.Get("Authorization") != "" || req.URL.User != nil {
+		return t.base.RoundTrip(req)
+	}
+
+	auth := t.lookup(req)
+	if auth == nil {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	if auth.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	} else {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// lookup returns the credentials of the host of req, matched with its port
+// first and then without it.
+func (t *authTransport) lookup(req *http.Request) *HTTPAuth {
+	for _, host := range []string{req.URL.Host, req.URL.Hostname()} {
+		if auth, ok := t.auth[host]; ok && auth != nil {
+			return auth
+		}
+	}
+	return t.netrc.lookup(req.URL.Hostname())
+}
+
+// lookup returns the credentials of the machine named host, if any.
+func (n *Netrc) lookup(host string) *HTTPAuth {
+	if n == nil {
+		return nil
+	}
+	machine := n.netrc.FindMachine(host)
+	if machine == nil || machine.IsDefault() {
+		return nil
+	}
+	return &HTTPAuth{
+		Username: machine.Login,
+		Password: machine.Password,
+	}
+}
+
+// ReadNetrc reads the netrc file given by $NETRC, or the one in the home
+// directory of the user. It returns nil if there is none.
+func ReadNetrc() (*Netrc, error) {
+	path := os.Getenv("NETRC")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		name := ".netrc"
+		if runtime.GOOS == "windows" {
+			name = "_netrc"
+		}
+		path = filepath.Join(home, name)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return nil, nil
+	}
+	n, err := netrc.ParseFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("task: failed to parse the netrc file %q: %w", path, err)
+	}
+	return &Netrc{netrc: n}, nil
+}
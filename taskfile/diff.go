@@ -0,0 +1,88 @@
+package taskfile
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// Diff returns a unified diff from the Taskfile a, called aName, to the
+// Taskfile b, called bName. Unless colors are disabled, the lines are syntax
+// highlighted like in a [Snippet] and the changes are colored. It returns an
+// empty string if the Taskfiles are equal.
+func Diff(aName string, a []byte, bName string, b []byte) string {
+	aLines, aHighlighted := diffLines(a)
+	bLines, bHighlighted := diffLines(b)
+
+	if slices.Equal(aLines, bLines) {
+		return ""
+	}
+
+	matcher := difflib.NewMatcherWithJunk(aLines, bLines, false, nil)
+	groups := matcher.GetGroupedOpCodes(diffContext)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, color.RedString("--- %s", aName))
+	fmt.Fprint(buf, color.GreenString("+++ %s", bName))
+	for _, group := range groups {
+		first, last := group[0], group[len(group)-1]
+		fmt.Fprintf(buf, "\n%s", color.CyanString("@@ -%s +%s @@",
+			diffRange(first.I1, last.I2),
+			diffRange(first.J1, last.J2),
+		))
+		for _, code := range group {
+			if code.Tag == 'e' {
+				for _, line := range aHighlighted[code.I1:code.I2] {
+					fmt.Fprintf(buf, "\n %s", line)
+				}
+				continue
+			}
+			if code.Tag == 'r' || code.Tag == 'd' {
+				for _, line := range aHighlighted[code.I1:code.I2] {
+					fmt.Fprintf(buf, "\n%s%s", color.RedString("-"), line)
+				}
+			}
+			if code.Tag == 'r' || code.Tag == 'i' {
+				for _, line := range bHighlighted[code.J1:code.J2] {
+					fmt.Fprintf(buf, "\n%s%s", color.GreenString("+"), line)
+				}
+			}
+		}
+	}
+	return buf.String()
+}
+
+// diffLines splits the Taskfile b into lines, both raw and highlighted. The
+// final newline does not start another line.
+func diffLines(b []byte) ([]string, []string) {
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if color.NoColor {
+		return lines, lines
+	}
+	highlighted := highlight(b)
+	if len(highlighted) < len(lines) {
+		return lines, lines
+	}
+	return lines, highlighted[:len(lines)]
+}
+
+// diffRange formats the lines from start to end, 0-indexed and exclusive, as a
+// range of a unified diff hunk.
+func diffRange(start, end int) string {
+	length := end - start
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, length)
+	}
+}
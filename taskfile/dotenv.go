@@ -13,7 +13,7 @@ import (
 
 func Dotenv(vars *ast.Vars, tf *ast.Taskfile, dir string) (*ast.Vars, error) {
 	env := ast.NewVars()
-	cache := &templater.Cache{Vars: vars}
+	cache := &templater.Cache{Vars: vars, Dir: dir}
 
 	for _, dotEnvPath := range tf.Dotenv {
 		dotEnvPath = templater.Replace(dotEnvPath, cache)
@@ -30,9 +30,13 @@ func Dotenv(vars *ast.Vars, tf *ast.Taskfile, dir string) (*ast.Vars, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error reading env file %s: %w", dotEnvPath, err)
 		}
+		origin := &ast.VarOrigin{
+			Layer:    ast.VarLayerDotenv,
+			Location: &ast.Location{Taskfile: dotEnvPath},
+		}
 		for key, value := range envs {
 			if _, ok := env.Get(key); !ok {
-				env.Set(key, ast.Var{Value: value})
+				env.Set(key, ast.Var{Value: value, Origin: origin})
 			}
 		}
 	}
@@ -0,0 +1,121 @@
+package taskfile
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// LockfileName is the name of the file, next to the root Taskfile, that
+// records the remote Taskfiles of a project.
+const LockfileName = "Taskfile.lock"
+
+const lockfileVersion = 1
+
+type (
+	// A Lockfile records what every remote Taskfile of a project, including the
+	// ones included transitively, resolved to when it was last locked. It is
+	// keyed by the location of the remote Taskfiles.
+	Lockfile struct {
+		Version int                      `yaml:"version"`
+		Remotes map[string]*LockedRemote `yaml:"remotes"`
+		mutex   sync.Mutex
+	}
+	// A LockedRemote is the entry of a remote Taskfile in a [Lockfile].
+	LockedRemote struct {
+		// URL is the URL the Taskfile was fetched from, after redirects.
+		URL string `yaml:"url"`
+		// Commit is the SHA of the commit a git ref or branch resolved to.
+		Commit string `yaml:"commit,omitempty"`
+		// Checksum is the checksum of the content of the Taskfile.
+		Checksum string `yaml:"checksum"`
+	}
+	// A resolvingNode is a [RemoteNode] that knows what it resolved to the last
+	// time it was read.
+	resolvingNode interface {
+		RemoteNode
+		resolved() (url string, commit string)
+	}
+)
+
+// NewLockfile returns an empty [Lockfile].
+func NewLockfile() *Lockfile {
+	return &Lockfile{
+		Version: lockfileVersion,
+		Remotes: map[string]*LockedRemote{},
+	}
+}
+
+// ReadLockfile reads the [Lockfile] at path. It returns nil if the file does
+// not exist.
+func ReadLockfile(path string) (*Lockfile, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lock := NewLockfile()
+	if err := yaml.Unmarshal(b, lock); err != nil {
+		return nil, err
+	}
+	if lock.Remotes == nil {
+		lock.Remotes = map[string]*LockedRemote{}
+	}
+	return lock, nil
+}
+
+// Write writes the [Lockfile] to path.
+func (lock *Lockfile) Write(path string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(lock); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Get returns the entry of the remote Taskfile at location.
+func (lock *Lockfile) Get(location string) (*LockedRemote, bool) {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	remote, ok := lock.Remotes[location]
+	return remote, ok
+}
+
+// Set sets the entry of the remote Taskfile at location.
+func (lock *Lockfile) Set(location string, remote *LockedRemote) {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	lock.Remotes[location] = remote
+}
+
+// Locations returns the sorted locations of the remote Taskfiles in the
+// [Lockfile].
+func (lock *Lockfile) Locations() []string {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+	return slices.Sorted(maps.Keys(lock.Remotes))
+}
+
+// lockRemote returns the entry of a remote node whose content is b.
+func lockRemote(node RemoteNode, b []byte) *LockedRemote {
+	remote := &LockedRemote{
+		URL:      node.Location(),
+		Checksum: checksum(b),
+	}
+	if node, ok := node.(resolvingNode); ok {
+		url, commit := node.resolved()
+		if url != "" {
+			remote.URL = url
+		}
+		remote.Commit = commit
+	}
+	return remote
+}
@@ -58,6 +58,8 @@ func NewNode(
 	}
 
 	switch scheme {
+	case "archive":
+		node, err = NewArchiveNode(entrypoint, dir, insecure, opts...)
 	case "git":
 		node, err = NewGitNode(entrypoint, dir, insecure, opts...)
 	case "http", "https":
@@ -70,6 +72,10 @@ func NewNode(
 }
 
 func IsRemoteEntrypoint(entrypoint string) bool {
+	// Archives are remote only when they are downloaded
+	if archive, _, ok := splitArchiveEntrypoint(entrypoint); ok {
+		return strings.HasPrefix(archive, "http://") || strings.HasPrefix(archive, "https://")
+	}
 	scheme, _ := getScheme(entrypoint)
 	switch scheme {
 	case "git", "http", "https":
@@ -80,6 +86,10 @@ func IsRemoteEntrypoint(entrypoint string) bool {
 }
 
 func getScheme(uri string) (string, error) {
+	if IsArchiveEntrypoint(uri) {
+		return "archive", nil
+	}
+
 	u, err := giturls.Parse(uri)
 	if u == nil {
 		return "", err
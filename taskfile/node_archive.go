@@ -0,0 +1,556 @@
+package taskfile
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/execext"
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/internal/fsext"
+)
+
+const archiveCacheDir = "archive"
+
+var archiveExtensions = []string{".tar.gz", ".tgz", ".zip"}
+
+// maxArchiveFileSize and maxArchiveSize bound the size of a file extracted from
+// an archive, and the size of the archive itself and of all its extracted
+// files, so that an archive can't fill the disk.
+const (
+	maxArchiveFileSize = 64 << 20
+	maxArchiveSize     = 256 << 20
+)
+
+// archiveMutex serializes the extraction of archives, since several Taskfiles
+// of the same archive can be read at the same time.
+var archiveMutex sync.Mutex
+
+// An ArchiveNode is a node that reads a Taskfile from a local tar.gz or zip
+// archive, e.g. "./vendor/tasks-1.2.tar.gz//Taskfile.yml". The archive is
+// extracted into the remote cache directory and the pinned checksum, if any, is
+// the checksum of the archive.
+type ArchiveNode struct {
+	*baseNode
+	archive string // path or URL of the archive
+	path    string // slash-separated path of the Taskfile inside the archive
+}
+
+// A RemoteArchiveNode is an [ArchiveNode] whose archive is downloaded over
+// HTTP(S).
+type RemoteArchiveNode struct {
+	*ArchiveNode
+	url         *url.URL
+	client      *http.Client
+	resolvedURL string // URL the archive was last read from, after redirects
+}
+
+// IsArchiveEntrypoint returns true if the entrypoint points to a Taskfile in a
+// tar.gz or zip archive.
+func IsArchiveEntrypoint(entrypoint string) bool {
+	_, _, ok := splitArchiveEntrypoint(entrypoint)
+	return ok
+}
+
+// splitArchiveEntrypoint splits an entrypoint like "tasks.tar.gz//Taskfile.yml"
+// into the archive and the path inside it.
+func splitArchiveEntrypoint(entrypoint string) (string, string, bool) {
+	var prefix string
+	rest := entrypoint
+	if scheme, after, ok := strings.Cut(entrypoint, "://"); ok {
+		prefix, rest = scheme+"://", after
+	}
+	archive, inner, _ := strings.Cut(rest, "//")
+	name := strings.ToLower(archive)
+	if i := strings.IndexAny(name, "?#"); i != -1 && prefix != "" {
+		name = name[:i]
+	}
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return prefix + archive, inner, true
+		}
+	}
+	return "", "", false
+}
+
+func NewArchiveNode(
+	entrypoint string,
+	dir string,
+	insecure bool,
+	opts ...NodeOption,
+) (Node, error) {
+	archive, inner, ok := splitArchiveEntrypoint(entrypoint)
+	if !ok {
+		return nil, fmt.Errorf("task: %q is not an archive", entrypoint)
+	}
+	inner = path.Clean("/" + inner)[1:]
+
+	base := NewBaseNode(dir, opts...)
+	if !IsRemoteEntrypoint(archive) {
+		archive, err := execext.ExpandLiteral(archive)
+		if err != nil {
+			return nil, err
+		}
+		archive, err = filepath.Abs(filepathext.SmartJoin(dir, archive))
+		if err != nil {
+			return nil, err
+		}
+		// The root Taskfile runs in the directory of the archive by default
+		if base.dir == "" {
+			base.dir = filepath.Dir(archive)
+		}
+		return &ArchiveNode{
+			baseNode: base,
+			archive:  archive,
+			path:     inner,
+		}, nil
+	}
+
+	u, err := url.Parse(archive)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "http" && !insecure {
+		return nil, &errors.TaskfileNotSecureError{URI: u.Redacted()}
+	}
+	client, err := BuildHTTPClient(insecure, base.caCert, base.cert, base.certKey)
+	if err != nil {
+		return nil, err
+	}
+	client = withAuth(client, base.auth, base.netrc)
+	return &RemoteArchiveNode{
+		ArchiveNode: &ArchiveNode{
+			baseNode: base,
+			archive:  u.Redacted(),
+			path:     inner,
+		},
+		url:    u,
+		client: client,
+	}, nil
+}
+
+func (node *ArchiveNode) Location() string {
+	if node.path == "" {
+		return node.archive
+	}
+	return node.archive + "//" + node.path
+}
+
+// Verify always returns true, as the pinned checksum is the one of the archive,
+// which is verified when the archive is extracted.
+func (node *ArchiveNode) Verify(checksum string) bool {
+	return true
+}
+
+func (node *ArchiveNode) Read() ([]byte, error) {
+	b, err := os.ReadFile(node.archive)
+	if err != nil {
+		return nil, err
+	}
+	return node.readFromArchive(b)
+}
+
+// readFromArchive extracts the archive b, unless it already was, and reads the
+// Taskfile from it.
+func (node *ArchiveNode) readFromArchive(b []byte) ([]byte, error) {
+	if err := node.extract(b); err != nil {
+		return nil, err
+	}
+	filePath, err := fsext.SearchPath(node.taskfileDir(node.path), DefaultTaskfiles)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filePath)
+}
+
+// extractDir returns the directory the archive is extracted into.
+func (node *ArchiveNode) extractDir() string {
+	cacheDir := cmp.Or(node.cacheDir, filepath.Join(node.Dir(), ".task"))
+	return filepath.Join(cacheDir, remoteCacheDir, archiveCacheDir, checksum([]byte(node.archive)))
+}
+
+// taskfileDir returns where the slash-separated path p of the archive is
+// extracted.
+func (node *ArchiveNode) taskfileDir(p string) string {
+	return filepath.Join(node.extractDir(), filepath.FromSlash(p))
+}
+
+// extractedTaskfileDir returns the directory of the extracted Taskfile.
+func (node *ArchiveNode) extractedTaskfileDir() string {
+	return node.taskfileDir(node.baseDir())
+}
+
+func (node *ArchiveNode) extract(b []byte) error {
+	sum := checksum(b)
+	if node.checksum != "" && node.checksum != sum {
+		return &errors.TaskfileDoesNotMatchChecksum{
+			URI:              node.archive,
+			ExpectedChecksum: node.checksum,
+			ActualChecksum:   sum,
+		}
+	}
+
+	archiveMutex.Lock()
+	defer archiveMutex.Unlock()
+
+	dst := node.extractDir()
+	checksumPath := dst + ".sha256"
+	if current, err := os.ReadFile(checksumPath); err == nil && string(current) == sum {
+		if _, err := os.Stat(dst); err == nil {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), ".extract-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if strings.HasSuffix(strings.ToLower(archivePath(node.archive)), ".zip") {
+		err = extractZip(b, tmp)
+	} else {
+		err = extractTarGz(b, tmp)
+	}
+	if err != nil {
+		return fmt.Errorf("task: failed to extract %q: %w", node.archive, err)
+	}
+
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return err
+	}
+	return os.WriteFile(checksumPath, []byte(sum), 0o644)
+}
+
+// An ExtractedArchive is an archive extracted in the cache.
+type ExtractedArchive struct {
+	// Path is the directory the archive is extracted into.
+	Path string
+	// Timestamp is when the archive was extracted.
+	Timestamp time.Time
+}
+
+// ReadExtractedArchives lists the archives extracted in the cache in dir.
+func ReadExtractedArchives(dir string) ([]*ExtractedArchive, error) {
+	dir = filepath.Join(dir, remoteCacheDir, archiveCacheDir)
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var archives []*ExtractedArchive
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), ".sha256")
+		if !ok {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, &ExtractedArchive{
+			Path:      filepath.Join(dir, name),
+			Timestamp: info.ModTime(),
+		})
+	}
+	return archives, nil
+}
+
+// Expired reports whether the archive was extracted longer than expiry ago.
+func (a *ExtractedArchive) Expired(expiry time.Duration) bool {
+	return time.Since(a.Timestamp) > expiry
+}
+
+// Remove deletes the extracted files of the archive, which is extracted again
+// the next time it is read.
+func (a *ExtractedArchive) Remove() error {
+	if err := os.Remove(a.Path + ".sha256"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(a.Path)
+}
+
+func (node *ArchiveNode) ResolveEntrypoint(entrypoint string) (string, error) {
+	// If the file is remote, we don't need to resolve the path
+	if IsRemoteEntrypoint(entrypoint) {
+		return entrypoint, nil
+	}
+
+	p, err := execext.ExpandLiteral(entrypoint)
+	if err != nil {
+		return "", err
+	}
+	if filepathext.IsAbs(p) {
+		return p, nil
+	}
+
+	// Relative includes are resolved inside the archive
+	resolved := path.Join(node.baseDir(), filepath.ToSlash(p))
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", fmt.Errorf("task: include %q escapes the archive %q", entrypoint, node.archive)
+	}
+	return node.archive + "//" + resolved, nil
+}
+
+func (node *ArchiveNode) ResolveDir(dir string) (string, error) {
+	p, err := execext.ExpandLiteral(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if filepathext.IsAbs(p) {
+		return p, nil
+	}
+
+	// NOTE: Uses the directory of the entrypoint (Taskfile), not the current working directory
+	// This means that files are included relative to one another
+	return filepathext.SmartJoin(node.extractedTaskfileDir(), p), nil
+}
+
+// baseDir returns the slash-separated directory of the Taskfile inside the
+// archive. The path is either the Taskfile or a directory containing one.
+func (node *ArchiveNode) baseDir() string {
+	if path.Ext(node.path) == "" {
+		return cmp.Or(node.path, ".")
+	}
+	return path.Dir(node.path)
+}
+
+func (node *RemoteArchiveNode) Read() ([]byte, error) {
+	return node.ReadContext(context.Background())
+}
+
+func (node *RemoteArchiveNode) ReadContext(ctx context.Context) ([]byte, error) {
+	b, resolvedURL, err := node.downloads.get(node.url.String(), func() ([]byte, string, error) {
+		return node.download(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	node.resolvedURL = resolvedURL
+	return node.readFromArchive(b)
+}
+
+// download fetches the archive, and returns it with the URL it was read from,
+// after redirects.
+func (node *RemoteArchiveNode) download(ctx context.Context) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", node.url.String(), nil)
+	if err != nil {
+		return nil, "", errors.TaskfileFetchFailedError{URI: node.Location()}
+	}
+
+	resp, err := node.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", err
+		}
+		return nil, "", errors.TaskfileFetchFailedError{URI: node.Location()}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errors.TaskfileFetchFailedError{
+			URI:            node.Location(),
+			HTTPStatusCode: resp.StatusCode,
+		}
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b) > maxArchiveSize {
+		return nil, "", fmt.Errorf("task: archive %q is larger than %d bytes", node.archive, maxArchiveSize)
+	}
+	return b, resp.Request.URL.Redacted(), nil
+}
+
+// archiveDownloads shares the downloads of remote archives between the nodes
+// of a read, since every Taskfile of an archive is a node of its own.
+type archiveDownloads struct {
+	mutex     sync.Mutex
+	downloads map[string]*archiveDownload
+}
+
+type archiveDownload struct {
+	once        sync.Once
+	b           []byte
+	resolvedURL string
+	err         error
+}
+
+func newArchiveDownloads() *archiveDownloads {
+	return &archiveDownloads{downloads: map[string]*archiveDownload{}}
+}
+
+// get returns the archive at url, which is downloaded only once.
+func (d *archiveDownloads) get(url string, download func() ([]byte, string, error)) ([]byte, string, error) {
+	if d == nil {
+		return download()
+	}
+	d.mutex.Lock()
+	dl, ok := d.downloads[url]
+	if !ok {
+		dl = &archiveDownload{}
+		d.downloads[url] = dl
+	}
+	d.mutex.Unlock()
+
+	dl.once.Do(func() {
+		dl.b, dl.resolvedURL, dl.err = download()
+	})
+	return dl.b, dl.resolvedURL, dl.err
+}
+
+func (node *RemoteArchiveNode) resolved() (string, string) {
+	if node.resolvedURL == "" || node.path == "" {
+		return node.resolvedURL, ""
+	}
+	return node.resolvedURL + "//" + node.path, ""
+}
+
+func (node *RemoteArchiveNode) CacheKey() string {
+	sum := strings.TrimRight(checksum([]byte(node.Location())), "=")
+	prefix := path.Base(archivePath(node.url.Path))
+	if node.path != "" {
+		prefix = fmt.Sprintf("%s.%s", prefix, path.Base(node.path))
+	}
+	return fmt.Sprintf("archive.%s.%s.%s", node.url.Host, prefix, sum)
+}
+
+// archivePath strips the query and fragment of an archive URL.
+func archivePath(archive string) string {
+	if i := strings.IndexAny(archive, "?#"); i != -1 && strings.Contains(archive, "://") {
+		return archive[:i]
+	}
+	return archive
+}
+
+// extractPath returns where name is extracted in dst, or an error if it would
+// be outside of dst.
+func extractPath(dst, name string) (string, error) {
+	p := filepath.Join(dst, filepath.FromSlash(name))
+	if p != dst && !strings.HasPrefix(p, dst+string(filepath.Separator)) {
+		return "", fmt.Errorf("%q is outside of the archive", name)
+	}
+	return p, nil
+}
+
+// writeArchiveFile writes the file name of an archive to p. remaining is the
+// number of bytes that can still be extracted from the archive, which the size
+// of the file is deducted from.
+func writeArchiveFile(p, name string, r io.Reader, mode os.FileMode, remaining *int64) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	limit := min(maxArchiveFileSize, *remaining)
+	n, err := io.Copy(f, io.LimitReader(r, limit+1))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if n > limit {
+		f.Close()
+		if limit == maxArchiveFileSize {
+			return fmt.Errorf("%q is larger than %d bytes", name, maxArchiveFileSize)
+		}
+		return fmt.Errorf("the extracted files are larger than %d bytes", maxArchiveSize)
+	}
+	*remaining -= n
+	return f.Close()
+}
+
+// extractTarGz extracts the directories and regular files of a tar.gz archive
+// into dst. Links are skipped, and the size of the files is bounded.
+func extractTarGz(b []byte, dst string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	remaining := int64(maxArchiveSize)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p, err := extractPath(dst, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(p, header.Name, tr, header.FileInfo().Mode(), &remaining); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts the directories and regular files of a zip archive into
+// dst, bounding the size of the files.
+func extractZip(b []byte, dst string) error {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return err
+	}
+	remaining := int64(maxArchiveSize)
+	for _, f := range zr.File {
+		p, err := extractPath(dst, f.Name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case f.FileInfo().IsDir():
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+		case f.Mode().IsRegular():
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			err = writeArchiveFile(p, f.Name, rc, f.Mode(), &remaining)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
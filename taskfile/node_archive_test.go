@@ -0,0 +1,225 @@
+package taskfile
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+var archiveFiles = map[string]string{
+	"Taskfile.yml": `version: '3'
+
+includes:
+  nested: ./nested/Taskfile.yml
+
+tasks:
+  hello:
+    cmds:
+      - echo hello
+`,
+	"nested/Taskfile.yml": `version: '3'
+
+tasks:
+  world:
+    cmds:
+      - echo world
+`,
+}
+
+func tarGzArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func readArchiveTaskfile(t *testing.T, entrypoint, dir string, opts ...ReaderOption) (*ast.Taskfile, error) {
+	t.Helper()
+	node, err := NewRootNode(entrypoint, dir, true, 0)
+	require.NoError(t, err)
+	opts = append(opts, WithTempDir(filepath.Join(dir, ".task")))
+	graph, err := NewReader(opts...).Read(context.Background(), node)
+	if err != nil {
+		return nil, err
+	}
+	return graph.Merge()
+}
+
+func TestSplitArchiveEntrypoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entrypoint string
+		archive    string
+		path       string
+		ok         bool
+	}{
+		{"./vendor/tasks-1.2.tar.gz//Taskfile.yml", "./vendor/tasks-1.2.tar.gz", "Taskfile.yml", true},
+		{"tasks.tgz", "tasks.tgz", "", true},
+		{"tasks.zip//sub/dir", "tasks.zip", "sub/dir", true},
+		{"https://example.com/tasks.tar.gz//Taskfile.yml", "https://example.com/tasks.tar.gz", "Taskfile.yml", true},
+		{"https://example.com/tasks.zip?token=abc//Taskfile.yml", "https://example.com/tasks.zip?token=abc", "Taskfile.yml", true},
+		{"./Taskfile.yml", "", "", false},
+		{"https://github.com/foo/bar.git//Taskfile.yml", "", "", false},
+	}
+
+	for _, tt := range tests {
+		archive, path, ok := splitArchiveEntrypoint(tt.entrypoint)
+		assert.Equal(t, tt.ok, ok, tt.entrypoint)
+		assert.Equal(t, tt.archive, archive, tt.entrypoint)
+		assert.Equal(t, tt.path, path, tt.entrypoint)
+	}
+}
+
+func TestArchiveNode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		archive string
+		content []byte
+	}{
+		{"tar.gz", "tasks-1.2.tar.gz", tarGzArchive(t, archiveFiles)},
+		{"zip", "tasks-1.2.zip", zipArchive(t, archiveFiles)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "vendor"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor", tt.archive), tt.content, 0o644))
+			taskfile := "version: '3'\n\nincludes:\n  lib:\n    taskfile: ./vendor/" + tt.archive + "//Taskfile.yml\n    checksum: " + checksum(tt.content) + "\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(taskfile), 0o644))
+
+			tf, err := readArchiveTaskfile(t, "", dir)
+			require.NoError(t, err)
+			_, ok := tf.Tasks.Get("lib:hello")
+			assert.True(t, ok)
+			world, ok := tf.Tasks.Get("lib:nested:world")
+			require.True(t, ok)
+			assert.Equal(t, filepath.Join(dir, "vendor", tt.archive)+"//nested/Taskfile.yml", world.Location.Taskfile)
+			assert.DirExists(t, filepath.Join(dir, ".task", remoteCacheDir, archiveCacheDir))
+		})
+	}
+}
+
+func TestArchiveNodeChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.tar.gz"), tarGzArchive(t, archiveFiles), 0o644))
+	taskfile := "version: '3'\n\nincludes:\n  lib:\n    taskfile: ./tasks.tar.gz//Taskfile.yml\n    checksum: abc\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(taskfile), 0o644))
+
+	_, err := readArchiveTaskfile(t, "", dir)
+	var mismatch *errors.TaskfileDoesNotMatchChecksum
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "abc", mismatch.ExpectedChecksum)
+}
+
+func TestArchiveNodePathTraversal(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	content := tarGzArchive(t, map[string]string{"../evil.yml": "version: '3'\n"})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.tar.gz"), content, 0o644))
+
+	_, err := readArchiveTaskfile(t, "tasks.tar.gz//evil.yml", dir)
+	require.ErrorContains(t, err, "outside of the archive")
+	assert.NoFileExists(t, filepath.Join(dir, ".task", remoteCacheDir, "evil.yml"))
+}
+
+func TestArchiveNodeEscapingInclude(t *testing.T) {
+	t.Parallel()
+
+	node, err := NewArchiveNode("/tmp/tasks.tar.gz//Taskfile.yml", "", false)
+	require.NoError(t, err)
+	_, err = node.ResolveEntrypoint("../Taskfile.yml")
+	require.ErrorContains(t, err, "escapes the archive")
+	entrypoint, err := node.ResolveEntrypoint("./nested")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/tasks.tar.gz//nested", entrypoint)
+}
+
+func TestRemoteArchiveNode(t *testing.T) {
+	t.Parallel()
+
+	content := tarGzArchive(t, archiveFiles)
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	taskfile := "version: '3'\n\nincludes:\n  lib:\n    taskfile: " + srv.URL + "/tasks.tar.gz//Taskfile.yml\n    checksum: " + checksum(content) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Taskfile.yml"), []byte(taskfile), 0o644))
+
+	tf, err := readArchiveTaskfile(t, "", dir, WithInsecure(true))
+	require.NoError(t, err)
+	world, ok := tf.Tasks.Get("lib:nested:world")
+	require.True(t, ok)
+	assert.Equal(t, srv.URL+"/tasks.tar.gz//nested/Taskfile.yml", world.Location.Taskfile)
+
+	// The nested Taskfile is read from the same download
+	assert.Equal(t, int32(1), downloads.Load())
+}
+
+func TestArchiveNodeTooLarge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	content := tarGzArchive(t, map[string]string{
+		"Taskfile.yml": "version: '3'\n",
+		"large.bin":    strings.Repeat("0", maxArchiveFileSize+1),
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.tar.gz"), content, 0o644))
+
+	_, err := readArchiveTaskfile(t, "tasks.tar.gz//Taskfile.yml", dir)
+	require.ErrorContains(t, err, `"large.bin" is larger than`)
+}
@@ -7,12 +7,16 @@ type (
 	// designed to be embedded in other node types so that this boilerplate code
 	// does not need to be repeated.
 	baseNode struct {
-		parent   Node
-		dir      string
-		checksum string
-		caCert   string
-		cert     string
-		certKey  string
+		parent    Node
+		dir       string
+		checksum  string
+		caCert    string
+		cert      string
+		certKey   string
+		cacheDir  string
+		auth      map[string]*HTTPAuth
+		netrc     *Netrc
+		downloads *archiveDownloads
 	}
 )
 
@@ -42,6 +46,30 @@ func WithChecksum(checksum string) NodeOption {
 	}
 }
 
+// WithCacheDir sets the directory under which nodes that need to unpack files,
+// like archives, store them.
+func WithCacheDir(cacheDir string) NodeOption {
+	return func(node *baseNode) {
+		node.cacheDir = cacheDir
+	}
+}
+
+// WithNetrc sets the netrc file whose credentials are sent to the hosts that
+// have none in the ones set by [WithAuth].
+func WithNetrc(n *Netrc) NodeOption {
+	return func(node *baseNode) {
+		node.netrc = n
+	}
+}
+
+// withArchiveDownloads shares the downloads of remote archives between the
+// nodes of a read.
+func withArchiveDownloads(downloads *archiveDownloads) NodeOption {
+	return func(node *baseNode) {
+		node.downloads = downloads
+	}
+}
+
 func (node *baseNode) Parent() Node {
 	return node.parent
 }
@@ -75,3 +103,11 @@ func WithCertKey(certKey string) NodeOption {
 		node.certKey = certKey
 	}
 }
+
+// WithAuth sets the credentials sent to the hosts of remote Taskfiles, keyed
+// by host.
+func WithAuth(auth map[string]*HTTPAuth) NodeOption {
+	return func(node *baseNode) {
+		node.auth = auth
+	}
+}
@@ -1,19 +1,46 @@
 package taskfile
 
 import (
+	"cmp"
+	"context"
 	"crypto/sha256"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/go-task/task/v3/errors"
 )
 
 const remoteCacheDir = "remote"
 
-type CacheNode struct {
-	*baseNode
-	source RemoteNode
-}
+// errNotModified is returned by a [conditionalNode] when the remote Taskfile
+// did not change since it was cached.
+var errNotModified = errors.New("task: remote Taskfile not modified")
+
+type (
+	CacheNode struct {
+		*baseNode
+		source RemoteNode
+	}
+	// cacheValidators identify the version of a cached remote Taskfile, so the
+	// server can tell whether it changed since.
+	cacheValidators struct {
+		etag         string
+		lastModified string
+	}
+	// A conditionalNode is a [RemoteNode] that can skip the download of a
+	// Taskfile that did not change since it was cached.
+	conditionalNode interface {
+		RemoteNode
+		// readIfModified reads the Taskfile and the validators of what it read.
+		// It returns errNotModified if the Taskfile still matches validators.
+		readIfModified(ctx context.Context, validators cacheValidators) ([]byte, cacheValidators, error)
+	}
+)
 
 func NewCacheNode(source RemoteNode, dir string) *CacheNode {
 	return &CacheNode{
@@ -29,7 +56,7 @@ func (node *CacheNode) Read() ([]byte, error) {
 }
 
 func (node *CacheNode) Write(data []byte) error {
-	if err := node.CreateCacheDir(); err != nil {
+	if err := node.writeURL(); err != nil {
 		return err
 	}
 	return os.WriteFile(node.Location(), data, 0o644)
@@ -54,18 +81,84 @@ func (node *CacheNode) WriteTimestamp(t time.Time) error {
 	return os.WriteFile(node.timestampPath(), []byte(t.Format(time.RFC3339)), 0o644)
 }
 
+func (node *CacheNode) readValidators() cacheValidators {
+	etag, _ := os.ReadFile(node.etagPath())
+	lastModified, _ := os.ReadFile(node.lastModifiedPath())
+	return cacheValidators{
+		etag:         string(etag),
+		lastModified: string(lastModified),
+	}
+}
+
+// writeValidators stores the validators of the cached Taskfile. Empty
+// validators are removed, so they never describe another version.
+func (node *CacheNode) writeValidators(validators cacheValidators) error {
+	if err := node.CreateCacheDir(); err != nil {
+		return err
+	}
+	for path, value := range map[string]string{
+		node.etagPath():         validators.etag,
+		node.lastModifiedPath(): validators.lastModified,
+	} {
+		if value == "" {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(path, []byte(value), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (node *CacheNode) ReadChecksum() string {
 	b, _ := os.ReadFile(node.checksumPath())
 	return string(b)
 }
 
 func (node *CacheNode) WriteChecksum(checksum string) error {
-	if err := node.CreateCacheDir(); err != nil {
+	if err := node.writeURL(); err != nil {
 		return err
 	}
 	return os.WriteFile(node.checksumPath(), []byte(checksum), 0o644)
 }
 
+// Trust caches b as the Taskfile of the source and trusts it, as if the user
+// accepted the prompt. It returns the checksum of b.
+func (node *CacheNode) Trust(b []byte) (string, error) {
+	checksum := checksum(b)
+	if err := node.WriteChecksum(checksum); err != nil {
+		return "", err
+	}
+	if err := node.WriteTimestamp(time.Now().UTC()); err != nil {
+		return "", err
+	}
+	// The validators of the previous version no longer apply
+	if err := node.writeValidators(cacheValidators{}); err != nil {
+		return "", err
+	}
+	return checksum, node.Write(b)
+}
+
+// RemoveChecksum removes the trusted checksum, so the user is prompted again
+// the next time the remote Taskfile is read.
+func (node *CacheNode) RemoveChecksum() error {
+	if err := os.Remove(node.checksumPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeURL records the location of the source, so the cache can be listed.
+func (node *CacheNode) writeURL() error {
+	if err := node.CreateCacheDir(); err != nil {
+		return err
+	}
+	return os.WriteFile(node.urlPath(), []byte(node.source.Location()), 0o644)
+}
+
 func (node *CacheNode) CreateCacheDir() error {
 	if err := os.MkdirAll(node.dir, 0o755); err != nil {
 		return err
@@ -102,10 +195,114 @@ func (node *CacheNode) timestampPath() string {
 	return node.filePath("timestamp")
 }
 
+func (node *CacheNode) urlPath() string {
+	return node.filePath("url")
+}
+
+func (node *CacheNode) etagPath() string {
+	return node.filePath("etag")
+}
+
+func (node *CacheNode) lastModifiedPath() string {
+	return node.filePath("last-modified")
+}
+
 func (node *CacheNode) filePath(suffix string) string {
 	return filepath.Join(node.dir, fmt.Sprintf("%s.%s", node.source.CacheKey(), suffix))
 }
 
+// A CacheEntry is a remote Taskfile in the cache, either downloaded or only
+// trusted.
+type CacheEntry struct {
+	// URL is the location of the remote Taskfile. Empty for entries cached by
+	// older versions of Task.
+	URL string `json:"url"`
+	// Key is the cache key of the remote Taskfile.
+	Key string `json:"key"`
+	// Timestamp is when the remote Taskfile was last downloaded.
+	Timestamp time.Time `json:"timestamp"`
+	// Checksum is the checksum of the remote Taskfile the user trusts.
+	Checksum string `json:"checksum"`
+	// Size is the size of the cached Taskfile, in bytes.
+	Size int64 `json:"size"`
+	// Path is the path of the cached Taskfile. Empty if it is only trusted.
+	Path string `json:"path,omitempty"`
+
+	dir string
+}
+
+var cacheSuffixes = []string{".yaml", ".checksum", ".timestamp", ".url", ".etag", ".last-modified"}
+
+// ReadCache lists the remote Taskfiles cached in dir, sorted by URL and key.
+func ReadCache(dir string) ([]*CacheEntry, error) {
+	dir = filepath.Join(dir, remoteCacheDir)
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := map[string]*CacheEntry{}
+	for _, file := range files {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		for _, suffix := range cacheSuffixes {
+			key, ok := strings.CutSuffix(file.Name(), suffix)
+			if !ok {
+				continue
+			}
+			entry, ok := entries[key]
+			if !ok {
+				entry = &CacheEntry{Key: key, dir: dir}
+				entries[key] = entry
+			}
+			path := filepath.Join(dir, file.Name())
+			b, _ := os.ReadFile(path)
+			switch suffix {
+			case ".yaml":
+				entry.Path = path
+				entry.Size = int64(len(b))
+			case ".checksum":
+				entry.Checksum = string(b)
+			case ".timestamp":
+				entry.Timestamp, _ = time.Parse(time.RFC3339, string(b))
+			case ".url":
+				entry.URL = string(b)
+			}
+			break
+		}
+	}
+
+	// Entries that are neither cached nor trusted anymore are left out
+	maps.DeleteFunc(entries, func(_ string, entry *CacheEntry) bool {
+		return entry.Path == "" && entry.Checksum == ""
+	})
+	return slices.SortedFunc(maps.Values(entries), func(a, b *CacheEntry) int {
+		return cmp.Or(cmp.Compare(a.URL, b.URL), cmp.Compare(a.Key, b.Key))
+	}), nil
+}
+
+// Expired reports whether the cached Taskfile was downloaded longer than
+// expiry ago.
+func (entry *CacheEntry) Expired(expiry time.Duration) bool {
+	return entry.Path != "" && time.Since(entry.Timestamp) > expiry
+}
+
+// Remove deletes the cached Taskfile of the entry. The checksum the user trusts
+// is kept, so the Taskfile is downloaded again without a prompt.
+func (entry *CacheEntry) Remove() error {
+	for _, suffix := range []string{".yaml", ".timestamp", ".etag", ".last-modified"} {
+		err := os.Remove(filepath.Join(entry.dir, entry.Key+suffix))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func checksum(b []byte) string {
 	h := sha256.New()
 	h.Write(b)
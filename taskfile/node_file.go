@@ -69,13 +69,25 @@ func (node *FileNode) ResolveEntrypoint(entrypoint string) (string, error) {
 		return "", err
 	}
 
+	// NOTE: Uses the directory of the entrypoint (Taskfile), not the current working directory
+	// This means that files are included relative to one another
+	entrypointDir := filepath.Dir(node.entrypoint)
+
+	// Only the archive is resolved, the path inside of it is kept as is
+	if archive, inner, ok := splitArchiveEntrypoint(path); ok {
+		if !filepathext.IsAbs(archive) {
+			archive = filepathext.SmartJoin(entrypointDir, archive)
+		}
+		if inner == "" {
+			return archive, nil
+		}
+		return archive + "//" + inner, nil
+	}
+
 	if filepathext.IsAbs(path) {
 		return path, nil
 	}
 
-	// NOTE: Uses the directory of the entrypoint (Taskfile), not the current working directory
-	// This means that files are included relative to one another
-	entrypointDir := filepath.Dir(node.entrypoint)
 	return filepathext.SmartJoin(entrypointDir, path), nil
 }
 
@@ -85,12 +97,24 @@ func (node *FileNode) ResolveDir(dir string) (string, error) {
 		return "", err
 	}
 
+	// NOTE: Uses the directory of the entrypoint (Taskfile), not the current working directory
+	// This means that files are included relative to one another
+	entrypointDir := filepath.Dir(node.entrypoint)
+
+	// Only the archive is resolved, the path inside of it is kept as is
+	if archive, inner, ok := splitArchiveEntrypoint(path); ok {
+		if !filepathext.IsAbs(archive) {
+			archive = filepathext.SmartJoin(entrypointDir, archive)
+		}
+		if inner == "" {
+			return archive, nil
+		}
+		return archive + "//" + inner, nil
+	}
+
 	if filepathext.IsAbs(path) {
 		return path, nil
 	}
 
-	// NOTE: Uses the directory of the entrypoint (Taskfile), not the current working directory
-	// This means that files are included relative to one another
-	entrypointDir := filepath.Dir(node.entrypoint)
 	return filepathext.SmartJoin(entrypointDir, path), nil
 }
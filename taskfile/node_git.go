@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -26,6 +27,8 @@ type GitNode struct {
 	rawUrl string
 	ref    string
 	path   string
+	commit string // SHA of the commit the ref resolved to when last read
+	file   string // path of the Taskfile in the cached repo when last read
 }
 
 type gitRepoCache struct {
@@ -182,10 +185,31 @@ func (node *GitNode) ReadContext(ctx context.Context) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	node.file = filePath
+
+	// Record the commit the ref resolved to, for the lockfile
+	if out, err := exec.CommandContext(ctx, "git", "-C", repoDir, "rev-parse", "HEAD").Output(); err == nil {
+		node.commit = strings.TrimSpace(string(out))
+	}
 
 	return b, nil
 }
 
+func (node *GitNode) readSignature(ctx context.Context, ext string) ([]byte, error) {
+	if node.file == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(node.file + ext)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (node *GitNode) resolved() (string, string) {
+	return node.url.Redacted(), node.commit
+}
+
 func (node *GitNode) ResolveEntrypoint(entrypoint string) (string, error) {
 	// If the file is remote, we don't need to resolve the path
 	if IsRemoteEntrypoint(entrypoint) {
@@ -1,6 +1,7 @@
 package taskfile
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -20,13 +21,14 @@ import (
 // An HTTPNode is a node that reads a Taskfile from a remote location via HTTP.
 type HTTPNode struct {
 	*baseNode
-	url    *url.URL     // stores url pointing actual remote file. (e.g. with Taskfile.yml)
-	client *http.Client // HTTP client with optional TLS configuration
+	url         *url.URL     // stores url pointing actual remote file. (e.g. with Taskfile.yml)
+	client      *http.Client // HTTP client with optional TLS configuration
+	resolvedURL string       // URL the file was last read from, after redirects
 }
 
-// buildHTTPClient creates an HTTP client with optional TLS configuration.
+// BuildHTTPClient creates an HTTP client with optional TLS configuration.
 // If no certificate options are provided, it returns http.DefaultClient.
-func buildHTTPClient(insecure bool, caCert, cert, certKey string) (*http.Client, error) {
+func BuildHTTPClient(insecure bool, caCert, cert, certKey string) (*http.Client, error) {
 	// Validate that cert and certKey are provided together
 	if (cert != "" && certKey == "") || (cert == "" && certKey != "") {
 		return nil, fmt.Errorf("both --cert and --cert-key must be provided together")
@@ -85,10 +87,11 @@ func NewHTTPNode(
 		return nil, &errors.TaskfileNotSecureError{URI: url.Redacted()}
 	}
 
-	client, err := buildHTTPClient(insecure, base.caCert, base.cert, base.certKey)
+	client, err := BuildHTTPClient(insecure, base.caCert, base.cert, base.certKey)
 	if err != nil {
 		return nil, err
 	}
+	client = withAuth(client, base.auth, base.netrc)
 
 	return &HTTPNode{
 		baseNode: base,
@@ -106,25 +109,67 @@ func (node *HTTPNode) Read() ([]byte, error) {
 }
 
 func (node *HTTPNode) ReadContext(ctx context.Context) ([]byte, error) {
+	b, _, err := node.readIfModified(ctx, cacheValidators{})
+	return b, err
+}
+
+func (node *HTTPNode) readIfModified(ctx context.Context, validators cacheValidators) ([]byte, cacheValidators, error) {
+	// A Taskfile that was cached is requested directly, so an unchanged one
+	// costs a single request instead of the probes of RemoteExists
+	if validators != (cacheValidators{}) {
+		resp, err := node.get(ctx, node.url, validators)
+		if err != nil {
+			return nil, cacheValidators{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotModified || (resp.StatusCode == http.StatusOK && isTaskfileContentType(resp.Header.Get("Content-Type"))) {
+			return node.readResponse(resp)
+		}
+	}
+
 	url, err := RemoteExists(ctx, *node.url, node.client)
 	if err != nil {
-		return nil, err
+		return nil, cacheValidators{}, err
+	}
+	resp, err := node.get(ctx, url, validators)
+	if err != nil {
+		return nil, cacheValidators{}, err
 	}
-	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	defer resp.Body.Close()
+	return node.readResponse(resp)
+}
+
+// get requests the Taskfile at u, unless it still matches validators.
+func (node *HTTPNode) get(ctx context.Context, u *url.URL, validators cacheValidators) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, errors.TaskfileFetchFailedError{URI: node.Location()}
 	}
+	if validators.etag != "" {
+		req.Header.Set("If-None-Match", validators.etag)
+	}
+	if validators.lastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.lastModified)
+	}
 
-	resp, err := node.client.Do(req.WithContext(ctx))
+	resp, err := node.client.Do(req)
 	if err != nil {
 		if ctx.Err() != nil {
 			return nil, err
 		}
 		return nil, errors.TaskfileFetchFailedError{URI: node.Location()}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.TaskfileFetchFailedError{
+	return resp, nil
+}
+
+func (node *HTTPNode) readResponse(resp *http.Response) ([]byte, cacheValidators, error) {
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		node.resolvedURL = resp.Request.URL.Redacted()
+		return nil, cacheValidators{}, errNotModified
+	default:
+		return nil, cacheValidators{}, errors.TaskfileFetchFailedError{
 			URI:            node.Location(),
 			HTTPStatusCode: resp.StatusCode,
 		}
@@ -132,11 +177,52 @@ func (node *HTTPNode) ReadContext(ctx context.Context) ([]byte, error) {
 
 	// Read the entire response body
 	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, cacheValidators{}, err
+	}
+
+	node.resolvedURL = resp.Request.URL.Redacted()
+	return b, cacheValidators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+func (node *HTTPNode) readSignature(ctx context.Context, ext string) ([]byte, error) {
+	u, err := url.Parse(cmp.Or(node.resolvedURL, node.url.String()) + ext)
 	if err != nil {
 		return nil, err
 	}
+	// The resolved URL is redacted, so the credentials come from the original one
+	u.User = node.url.User
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, errors.TaskfileFetchFailedError{URI: node.Location() + ext}
+	}
+
+	resp, err := node.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, errors.TaskfileFetchFailedError{URI: node.Location() + ext}
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, errors.TaskfileFetchFailedError{
+			URI:            node.Location() + ext,
+			HTTPStatusCode: resp.StatusCode,
+		}
+	}
+}
 
-	return b, nil
+func (node *HTTPNode) resolved() (string, string) {
+	return node.resolvedURL, ""
 }
 
 func (node *HTTPNode) ResolveEntrypoint(entrypoint string) (string, error) {
@@ -24,4 +24,10 @@ func TestScheme(t *testing.T) {
 	scheme, err = getScheme("https://some-azure-host.com/org/project/_git/repo")
 	assert.NoError(t, err)
 	assert.Equal(t, "git", scheme)
+	scheme, err = getScheme("./vendor/tasks-1.2.tar.gz//Taskfile.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, "archive", scheme)
+	scheme, err = getScheme("https://example.com/tasks.zip//Taskfile.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, "archive", scheme)
 }
@@ -34,6 +34,9 @@ type (
 	DebugFunc func(string)
 	// PromptFunc is a function that can be called to prompt the user for input.
 	PromptFunc func(string) error
+	// DiffPromptFunc is a function that can be called to prompt the user for
+	// input about a change, described by a diff.
+	DiffPromptFunc func(prompt string, diff string) error
 	// A ReaderOption is any type that can apply a configuration to a [Reader].
 	ReaderOption interface {
 		ApplyToReader(*Reader)
@@ -46,14 +49,22 @@ type (
 		download            bool
 		offline             bool
 		trustedHosts        []string
+		trustedKeys         []string
 		tempDir             string
 		cacheExpiryDuration time.Duration
 		caCert              string
 		cert                string
 		certKey             string
+		auth                map[string]*HTTPAuth
+		netrc               *Netrc
 		debugFunc           DebugFunc
 		promptFunc          PromptFunc
+		diffPromptFunc      DiffPromptFunc
 		promptMutex         sync.Mutex
+		lockfile            *Lockfile
+		updateLock          bool
+		locked              *Lockfile
+		archiveDownloads    *archiveDownloads
 	}
 )
 
@@ -66,11 +77,17 @@ func NewReader(opts ...ReaderOption) *Reader {
 		download:            false,
 		offline:             false,
 		trustedHosts:        nil,
+		trustedKeys:         nil,
 		tempDir:             os.TempDir(),
 		cacheExpiryDuration: 0,
 		debugFunc:           nil,
 		promptFunc:          nil,
+		diffPromptFunc:      nil,
 		promptMutex:         sync.Mutex{},
+		lockfile:            nil,
+		updateLock:          false,
+		locked:              NewLockfile(),
+		archiveDownloads:    newArchiveDownloads(),
 	}
 	r.Options(opts...)
 	return r
@@ -140,6 +157,21 @@ func (o *trustedHostsOption) ApplyToReader(r *Reader) {
 	r.trustedHosts = o.trustedHosts
 }
 
+// WithTrustedKeys configures the [Reader] with the public keys remote Taskfiles
+// can be signed with. Taskfiles with a valid signature from one of these keys
+// will not prompt for user confirmation, while invalid signatures are an error.
+func WithTrustedKeys(trustedKeys []string) ReaderOption {
+	return &trustedKeysOption{trustedKeys: trustedKeys}
+}
+
+type trustedKeysOption struct {
+	trustedKeys []string
+}
+
+func (o *trustedKeysOption) ApplyToReader(r *Reader) {
+	r.trustedKeys = o.trustedKeys
+}
+
 // WithTempDir sets the temporary directory that will be used by the [Reader].
 // By default, the reader uses [os.TempDir].
 func WithTempDir(tempDir string) ReaderOption {
@@ -203,6 +235,23 @@ func (o *promptFuncOption) ApplyToReader(r *Reader) {
 	r.promptFunc = o.promptFunc
 }
 
+// WithDiffPromptFunc sets the function to be used by the [Reader] to prompt the
+// user when a remote Taskfile changed since it was cached. It is called with the
+// prompt message and a diff from the cached to the downloaded Taskfile, and
+// should behave like the function given to [WithPromptFunc]. By default, no
+// diff prompt function is set and the prompt function is used without the diff.
+func WithDiffPromptFunc(diffPromptFunc DiffPromptFunc) ReaderOption {
+	return &diffPromptFuncOption{diffPromptFunc: diffPromptFunc}
+}
+
+type diffPromptFuncOption struct {
+	diffPromptFunc DiffPromptFunc
+}
+
+func (o *diffPromptFuncOption) ApplyToReader(r *Reader) {
+	r.diffPromptFunc = o.diffPromptFunc
+}
+
 // WithReaderCACert sets the path to a custom CA certificate for TLS connections.
 func WithReaderCACert(caCert string) ReaderOption {
 	return &readerCACertOption{caCert: caCert}
@@ -242,6 +291,69 @@ func (o *readerCertKeyOption) ApplyToReader(r *Reader) {
 	r.certKey = o.certKey
 }
 
+// WithReaderAuth sets the credentials sent to the hosts of remote Taskfiles,
+// keyed by host.
+func WithReaderAuth(auth map[string]*HTTPAuth) ReaderOption {
+	return &readerAuthOption{auth: auth}
+}
+
+type readerAuthOption struct {
+	auth map[string]*HTTPAuth
+}
+
+func (o *readerAuthOption) ApplyToReader(r *Reader) {
+	r.auth = o.auth
+}
+
+// WithReaderNetrc sets the netrc file whose credentials are sent to the hosts
+// of remote Taskfiles that have none in the ones set by [WithReaderAuth].
+func WithReaderNetrc(netrc *Netrc) ReaderOption {
+	return &readerNetrcOption{netrc: netrc}
+}
+
+type readerNetrcOption struct {
+	netrc *Netrc
+}
+
+func (o *readerNetrcOption) ApplyToReader(r *Reader) {
+	r.netrc = o.netrc
+}
+
+// WithLockfile configures the [Reader] to verify every remote Taskfile against
+// the given [Lockfile]. By default, or if the lockfile is nil, remote Taskfiles
+// are not verified.
+func WithLockfile(lockfile *Lockfile) ReaderOption {
+	return &lockfileOption{lockfile: lockfile}
+}
+
+type lockfileOption struct {
+	lockfile *Lockfile
+}
+
+func (o *lockfileOption) ApplyToReader(r *Reader) {
+	r.lockfile = o.lockfile
+}
+
+// WithUpdateLock allows remote Taskfiles to drift from the [Lockfile] given to
+// [WithLockfile]. The drifted Taskfiles are recorded in [Reader.Lockfile] so it
+// can be written back.
+func WithUpdateLock(updateLock bool) ReaderOption {
+	return &updateLockOption{updateLock: updateLock}
+}
+
+type updateLockOption struct {
+	updateLock bool
+}
+
+func (o *updateLockOption) ApplyToReader(r *Reader) {
+	r.updateLock = o.updateLock
+}
+
+// Lockfile returns a [Lockfile] of the remote Taskfiles read by the [Reader].
+func (r *Reader) Lockfile() *Lockfile {
+	return r.locked
+}
+
 // Read will read the Taskfile defined by the [Reader]'s [Node] and recurse
 // through any [ast.Includes] it finds, reading each included Taskfile and
 // building an [ast.TaskfileGraph] as it goes. If any errors occur, they will be
@@ -252,6 +364,11 @@ func (r *Reader) Read(ctx context.Context, node Node) (*ast.TaskfileGraph, error
 		_ = CleanGitCache()
 	}()
 
+	// The Taskfiles included by a root archive are read from its download
+	if archive, ok := node.(*RemoteArchiveNode); ok && archive.downloads == nil {
+		archive.downloads = r.archiveDownloads
+	}
+
 	if err := r.include(ctx, node); err != nil {
 		return nil, err
 	}
@@ -272,6 +389,13 @@ func (r *Reader) promptf(format string, a ...any) error {
 	return nil
 }
 
+func (r *Reader) diffPromptf(diff string, format string, a ...any) error {
+	if r.diffPromptFunc != nil {
+		return r.diffPromptFunc(fmt.Sprintf(format, a...), diff)
+	}
+	return r.promptf(format, a...)
+}
+
 // isTrusted checks if a URI's host matches any of the trusted hosts patterns.
 func (r *Reader) isTrusted(uri string) bool {
 	if len(r.trustedHosts) == 0 {
@@ -355,6 +479,10 @@ func (r *Reader) include(ctx context.Context, node Node) error {
 				WithCACert(r.caCert),
 				WithCert(r.cert),
 				WithCertKey(r.certKey),
+				WithAuth(r.auth),
+				WithNetrc(r.netrc),
+				WithCacheDir(r.tempDir),
+				withArchiveDownloads(r.archiveDownloads),
 			)
 			if err != nil {
 				if include.Optional {
@@ -429,8 +557,19 @@ func (r *Reader) readNode(ctx context.Context, node Node) (*ast.Taskfile, error)
 		return nil, &errors.TaskfileVersionCheckError{URI: node.Location()}
 	}
 
-	// Set the taskfile/task's locations
+	// Taskfiles of archives only exist where the archive was extracted
+	var taskfileDir string
+	if archive, ok := node.(interface{ extractedTaskfileDir() string }); ok {
+		taskfileDir = archive.extractedTaskfileDir()
+	}
+
+	// Set the taskfile/task/var's locations
 	tf.Location = node.Location()
+	tf.Vars.SetLocationTaskfile(tf.Location)
+	tf.Env.SetLocationTaskfile(tf.Location)
+	for include := range tf.Includes.Values() {
+		include.Vars.SetLocationTaskfile(tf.Location)
+	}
 	for task := range tf.Tasks.Values(nil) {
 		// If the task is not defined, create a new one
 		if task == nil {
@@ -440,6 +579,24 @@ func (r *Reader) readNode(ctx context.Context, node Node) (*ast.Taskfile, error)
 		if task.Location.Taskfile == "" {
 			task.Location.Taskfile = tf.Location
 		}
+		task.TaskfileDir = taskfileDir
+		task.Vars.SetLocationTaskfile(tf.Location)
+		task.Env.SetLocationTaskfile(tf.Location)
+		for _, cmd := range task.Cmds {
+			if cmd != nil {
+				cmd.Vars.SetLocationTaskfile(tf.Location)
+			}
+		}
+		for _, dep := range task.Deps {
+			if dep != nil {
+				dep.Vars.SetLocationTaskfile(tf.Location)
+			}
+		}
+		for _, v := range task.RequiredVars() {
+			if v != nil && v.Location != nil {
+				v.Location.Taskfile = tf.Location
+			}
+		}
 	}
 
 	return &tf, nil
@@ -447,7 +604,11 @@ func (r *Reader) readNode(ctx context.Context, node Node) (*ast.Taskfile, error)
 
 func (r *Reader) readNodeContent(ctx context.Context, node Node) ([]byte, error) {
 	if node, isRemote := node.(RemoteNode); isRemote {
-		return r.readRemoteNodeContent(ctx, node)
+		b, err := r.readRemoteNodeContent(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		return r.verifyLock(node, b)
 	}
 
 	// Read the Taskfile
@@ -516,9 +677,18 @@ func (r *Reader) readRemoteNodeContent(ctx context.Context, node RemoteNode) ([]
 		cacheFound = true
 	}
 
-	// Try to read the remote file
+	// Try to read the remote file, unless it did not change since it was cached
+	var validators cacheValidators
+	if cacheFound {
+		validators = cache.readValidators()
+	}
 	r.debugf("downloading remote file: %s\n", node.Location())
-	downloadedBytes, err := node.ReadContext(ctx)
+	downloadedBytes, validators, err := readIfModified(ctx, node, validators)
+	notModified := errors.Is(err, errNotModified)
+	if notModified {
+		r.debugf("remote file not modified, using cache\n")
+		downloadedBytes, err = cachedBytes, nil
+	}
 	if err != nil {
 		// If the context timed out or was cancelled, but we found a cached version, use that
 		if ctx.Err() != nil && cacheFound {
@@ -534,6 +704,12 @@ func (r *Reader) readRemoteNodeContent(ctx context.Context, node RemoteNode) ([]
 
 	r.debugf("found remote file at %q\n", node.Location())
 
+	// Verify the detached signature of the file, if it has one
+	signed, err := r.verifySignature(ctx, node, downloadedBytes)
+	if err != nil {
+		return nil, err
+	}
+
 	// If the given checksum doesn't match the sum pinned in the Taskfile
 	checksum := checksum(downloadedBytes)
 	if !node.Verify(checksum) {
@@ -544,14 +720,28 @@ func (r *Reader) readRemoteNodeContent(ctx context.Context, node RemoteNode) ([]
 		}
 	}
 
-	// If there is no manual checksum pin, run the automatic checks
-	if node.Checksum() == "" {
+	// A lockfile rejects drifted content before it is trusted or cached
+	if r.lockfile != nil && !r.updateLock {
+		if _, err := r.verifyLock(node, downloadedBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	// If there is no manual checksum pin nor a lockfile entry, run the automatic checks
+	if node.Checksum() == "" && !r.verifiedByLock(node, checksum) {
 		// Prompt the user if required (unless host is trusted)
 		prompt := cache.ChecksumPrompt(checksum)
-		if prompt != "" && !r.isTrusted(node.Location()) {
+		if prompt != "" && !signed && !r.isTrusted(node.Location()) {
 			if err := func() error {
 				r.promptMutex.Lock()
 				defer r.promptMutex.Unlock()
+				// Show what changed since the Taskfile was trusted
+				if prompt == taskfileChangedPrompt {
+					if cachedBytes, err := cache.Read(); err == nil {
+						diff := Diff(node.Location()+" (cached)", cachedBytes, node.Location()+" (downloaded)", downloadedBytes)
+						return r.diffPromptf(diff, prompt, node.Location())
+					}
+				}
 				return r.promptf(prompt, node.Location())
 			}(); err != nil {
 				return nil, &errors.TaskfileNotTrustedError{URI: node.Location()}
@@ -569,11 +759,90 @@ func (r *Reader) readRemoteNodeContent(ctx context.Context, node RemoteNode) ([]
 		return nil, err
 	}
 
+	// The cached file is still up to date
+	if notModified {
+		return downloadedBytes, nil
+	}
+
 	// Cache the file
 	r.debugf("caching %q to %q\n", node.Location(), cache.Location())
 	if err = cache.Write(downloadedBytes); err != nil {
 		return nil, err
 	}
+	if err := cache.writeValidators(validators); err != nil {
+		return nil, err
+	}
 
 	return downloadedBytes, nil
 }
+
+// readIfModified reads the remote node, or returns errNotModified if it
+// supports conditional requests and did not change since it was cached with
+// the given validators.
+func readIfModified(ctx context.Context, node RemoteNode, validators cacheValidators) ([]byte, cacheValidators, error) {
+	if node, ok := node.(conditionalNode); ok {
+		return node.readIfModified(ctx, validators)
+	}
+	b, err := node.ReadContext(ctx)
+	return b, cacheValidators{}, err
+}
+
+// verifyLock records the remote node whose content is b and verifies it against
+// the lockfile, unless the lock is being updated.
+func (r *Reader) verifyLock(node RemoteNode, b []byte) ([]byte, error) {
+	remote := lockRemote(node, b)
+	r.locked.Set(node.Location(), remote)
+	if r.lockfile == nil || r.updateLock {
+		return b, nil
+	}
+
+	locked, ok := r.lockfile.Get(node.Location())
+	switch {
+	case !ok:
+		return nil, &errors.TaskfileLockMismatchError{URI: node.Location()}
+	case locked.Checksum != remote.Checksum:
+		return nil, &errors.TaskfileLockMismatchError{
+			URI:      node.Location(),
+			Field:    "checksum",
+			Expected: locked.Checksum,
+			Actual:   remote.Checksum,
+		}
+	// The commit is unknown when the Taskfile is read from the cache
+	case locked.Commit != "" && remote.Commit != "" && locked.Commit != remote.Commit:
+		return nil, &errors.TaskfileLockMismatchError{
+			URI:      node.Location(),
+			Field:    "commit",
+			Expected: locked.Commit,
+			Actual:   remote.Commit,
+		}
+	}
+	return b, nil
+}
+
+// verifiedByLock returns true if the remote node does not need to be trusted by
+// the user, because the lockfile records the given checksum for it.
+func (r *Reader) verifiedByLock(node RemoteNode, checksum string) bool {
+	if r.lockfile == nil {
+		return false
+	}
+	locked, ok := r.lockfile.Get(node.Location())
+	return ok && locked.Checksum == checksum
+}
+
+// verifySignature verifies the detached signature of the remote node whose
+// content is b against the trusted keys. It returns true if the signature is
+// valid, and false if there is none.
+func (r *Reader) verifySignature(ctx context.Context, node RemoteNode, b []byte) (bool, error) {
+	if len(r.trustedKeys) == 0 {
+		return false, nil
+	}
+	keys, err := parseTrustedKeys(r.trustedKeys)
+	if err != nil {
+		return false, err
+	}
+	signed, err := verifySignature(ctx, node, b, keys)
+	if signed {
+		r.debugf("verified the signature of %q\n", node.Location())
+	}
+	return signed, err
+}
@@ -0,0 +1,233 @@
+package taskfile
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+const (
+	// minisignExtension is the extension of the minisign signature of a
+	// Taskfile, e.g. "Taskfile.yml.minisig".
+	minisignExtension = ".minisig"
+	// sshSignatureExtension is the extension of the SSH signature of a
+	// Taskfile, e.g. "Taskfile.yml.sig", as made by "ssh-keygen -Y sign -n file".
+	sshSignatureExtension = ".sig"
+	// sshSignatureNamespace is the namespace SSH signatures of Taskfiles must
+	// be made for.
+	sshSignatureNamespace = "file"
+)
+
+type (
+	// A signedNode is a [RemoteNode] whose Taskfile can have a detached
+	// signature next to it.
+	signedNode interface {
+		RemoteNode
+		// readSignature reads the signature of the Taskfile with the given
+		// extension. It returns nil if there is no such signature.
+		readSignature(ctx context.Context, ext string) ([]byte, error)
+	}
+	// trustedKey is a public key that remote Taskfiles can be signed with.
+	trustedKey struct {
+		minisign *minisignKey
+		ssh      ssh.PublicKey
+	}
+	minisignKey struct {
+		id  [8]byte
+		key ed25519.PublicKey
+	}
+)
+
+// parseTrustedKeys parses public keys given either as minisign public keys or
+// as SSH public keys in the authorized_keys format.
+func parseTrustedKeys(keys []string) ([]*trustedKey, error) {
+	trustedKeys := make([]*trustedKey, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if strings.HasPrefix(key, "ssh-") || strings.HasPrefix(key, "ecdsa-") {
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+			if err != nil {
+				return nil, fmt.Errorf("task: invalid trusted key %q: %w", key, err)
+			}
+			trustedKeys = append(trustedKeys, &trustedKey{ssh: pub})
+			continue
+		}
+		minisign, err := parseMinisignKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("task: invalid trusted key %q: %w", key, err)
+		}
+		trustedKeys = append(trustedKeys, &trustedKey{minisign: minisign})
+	}
+	return trustedKeys, nil
+}
+
+// parseMinisignKey parses a minisign public key, with or without its untrusted
+// comment line.
+func parseMinisignKey(key string) (*minisignKey, error) {
+	lines := strings.Split(strings.TrimSpace(key), "\n")
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 2+8+ed25519.PublicKeySize || string(b[:2]) != "Ed" {
+		return nil, fmt.Errorf("not an ed25519 minisign public key")
+	}
+	k := &minisignKey{key: ed25519.PublicKey(b[10:])}
+	copy(k.id[:], b[2:10])
+	return k, nil
+}
+
+// verifySignature verifies the detached signature of the Taskfile b of node
+// with the trusted keys. It returns false if the Taskfile is not signed, and an
+// error if the signature is not valid or not made by a trusted key.
+func verifySignature(ctx context.Context, node RemoteNode, b []byte, keys []*trustedKey) (bool, error) {
+	signed, ok := node.(signedNode)
+	if !ok || len(keys) == 0 {
+		return false, nil
+	}
+
+	verifiers := []struct {
+		ext    string
+		verify func(keys []*trustedKey, message, sig []byte) error
+	}{
+		{minisignExtension, verifyMinisign},
+		{sshSignatureExtension, verifySSHSignature},
+	}
+	for _, v := range verifiers {
+		sig, err := signed.readSignature(ctx, v.ext)
+		if err != nil {
+			return false, err
+		}
+		if sig == nil {
+			continue
+		}
+		if err := v.verify(keys, b, sig); err != nil {
+			return false, &errors.TaskfileSignatureInvalidError{
+				URI:    node.Location(),
+				Reason: err.Error(),
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// verifyMinisign verifies a minisign signature, including its trusted comment.
+func verifyMinisign(keys []*trustedKey, message, sig []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	if len(lines) < 4 {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sigBytes) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	trustedComment, ok := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !ok {
+		return fmt.Errorf("malformed minisign signature: missing trusted comment")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+
+	// "ED" signatures are made over the BLAKE2b-512 hash of the file
+	switch string(sigBytes[:2]) {
+	case "Ed":
+	case "ED":
+		sum := blake2b.Sum512(message)
+		message = sum[:]
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", sigBytes[:2])
+	}
+
+	for _, key := range keys {
+		if key.minisign == nil || !bytes.Equal(key.minisign.id[:], sigBytes[2:10]) {
+			continue
+		}
+		if !ed25519.Verify(key.minisign.key, message, sigBytes[10:]) {
+			return fmt.Errorf("the minisign signature does not match the Taskfile")
+		}
+		if !ed25519.Verify(key.minisign.key, slices.Concat(sigBytes[10:], []byte(trustedComment)), globalSig) {
+			return fmt.Errorf("the trusted comment of the minisign signature was tampered with")
+		}
+		return nil
+	}
+	return fmt.Errorf("the minisign signature was not made by a trusted key")
+}
+
+// verifySSHSignature verifies an armored SSH signature, as specified by
+// OpenSSH's PROTOCOL.sshsig.
+func verifySSHSignature(keys []*trustedKey, message, sig []byte) error {
+	block, _ := pem.Decode(sig)
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return fmt.Errorf("malformed SSH signature")
+	}
+	blob, ok := bytes.CutPrefix(block.Bytes, []byte("SSHSIG"))
+	if !ok {
+		return fmt.Errorf("malformed SSH signature")
+	}
+	var sshSig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(blob, &sshSig); err != nil {
+		return fmt.Errorf("malformed SSH signature: %w", err)
+	}
+	if sshSig.Version != 1 {
+		return fmt.Errorf("unsupported SSH signature version %d", sshSig.Version)
+	}
+	if sshSig.Namespace != sshSignatureNamespace {
+		return fmt.Errorf("the SSH signature is for the namespace %q instead of %q", sshSig.Namespace, sshSignatureNamespace)
+	}
+
+	var h hash.Hash
+	switch sshSig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported SSH signature hash algorithm %q", sshSig.HashAlgorithm)
+	}
+	h.Write(message)
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sshSig.Namespace, sshSig.Reserved, sshSig.HashAlgorithm, h.Sum(nil)})...)
+
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(sshSig.Signature, &signature); err != nil {
+		return fmt.Errorf("malformed SSH signature: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.ssh == nil || !bytes.Equal(key.ssh.Marshal(), sshSig.PublicKey) {
+			continue
+		}
+		if err := key.ssh.Verify(signedData, &signature); err != nil {
+			return fmt.Errorf("the SSH signature does not match the Taskfile")
+		}
+		return nil
+	}
+	return fmt.Errorf("the SSH signature was not made by a trusted key")
+}
@@ -0,0 +1,212 @@
+package taskfile
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+const signedTaskfile = "version: '3'\n\ntasks:\n  hello: echo hello\n"
+
+type minisignTestKey struct {
+	id   [8]byte
+	priv ed25519.PrivateKey
+}
+
+func newMinisignTestKey(t *testing.T) *minisignTestKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key := &minisignTestKey{priv: priv}
+	_, err = rand.Read(key.id[:])
+	require.NoError(t, err)
+	return key
+}
+
+func (k *minisignTestKey) public() string {
+	b := append([]byte("Ed"), k.id[:]...)
+	b = append(b, k.priv.Public().(ed25519.PublicKey)...)
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(b)
+}
+
+func (k *minisignTestKey) sign(message []byte, prehashed bool) []byte {
+	alg := "Ed"
+	if prehashed {
+		alg = "ED"
+		sum := blake2b.Sum512(message)
+		message = sum[:]
+	}
+	sig := ed25519.Sign(k.priv, message)
+	trustedComment := "timestamp:1700000000\tfile:Taskfile.yml"
+	globalSig := ed25519.Sign(k.priv, append(append([]byte{}, sig...), trustedComment...))
+	b := append([]byte(alg), k.id[:]...)
+	b = append(b, sig...)
+	return fmt.Appendf(nil,
+		"untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(b),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSig),
+	)
+}
+
+func sshSign(t *testing.T, priv ed25519.PrivateKey, namespace string, message []byte) []byte {
+	t.Helper()
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	hash := sha512.Sum512(message)
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{namespace, "", "sha512", hash[:]})...)
+	sig, err := signer.Sign(rand.Reader, signedData)
+	require.NoError(t, err)
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), namespace, "", "sha512", ssh.Marshal(sig)})...)
+	return pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob})
+}
+
+func sshPublicKey(t *testing.T, priv ed25519.PrivateKey) string {
+	t.Helper()
+	pub, err := ssh.NewPublicKey(priv.Public())
+	require.NoError(t, err)
+	return string(ssh.MarshalAuthorizedKey(pub))
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	trusted := newMinisignTestKey(t)
+	untrusted := newMinisignTestKey(t)
+	_, sshTrusted, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, sshUntrusted, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := parseTrustedKeys([]string{trusted.public(), sshPublicKey(t, sshTrusted)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		signatures map[string][]byte
+		signed     bool
+		err        string
+	}{
+		{
+			name: "unsigned",
+		},
+		{
+			name:       "minisign",
+			signatures: map[string][]byte{minisignExtension: trusted.sign([]byte(signedTaskfile), false)},
+			signed:     true,
+		},
+		{
+			name:       "minisign prehashed",
+			signatures: map[string][]byte{minisignExtension: trusted.sign([]byte(signedTaskfile), true)},
+			signed:     true,
+		},
+		{
+			name:       "minisign of another file",
+			signatures: map[string][]byte{minisignExtension: trusted.sign([]byte("version: '3'\n"), false)},
+			err:        "does not match the Taskfile",
+		},
+		{
+			name:       "minisign untrusted key",
+			signatures: map[string][]byte{minisignExtension: untrusted.sign([]byte(signedTaskfile), false)},
+			err:        "not made by a trusted key",
+		},
+		{
+			name:       "ssh",
+			signatures: map[string][]byte{sshSignatureExtension: sshSign(t, sshTrusted, "file", []byte(signedTaskfile))},
+			signed:     true,
+		},
+		{
+			name:       "ssh of another file",
+			signatures: map[string][]byte{sshSignatureExtension: sshSign(t, sshTrusted, "file", []byte("version: '3'\n"))},
+			err:        "does not match the Taskfile",
+		},
+		{
+			name:       "ssh untrusted key",
+			signatures: map[string][]byte{sshSignatureExtension: sshSign(t, sshUntrusted, "file", []byte(signedTaskfile))},
+			err:        "not made by a trusted key",
+		},
+		{
+			name:       "ssh wrong namespace",
+			signatures: map[string][]byte{sshSignatureExtension: sshSign(t, sshTrusted, "git", []byte(signedTaskfile))},
+			err:        "namespace",
+		},
+		{
+			name:       "malformed",
+			signatures: map[string][]byte{minisignExtension: []byte("garbage")},
+			err:        "malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/Taskfile.yml" {
+					_, _ = w.Write([]byte(signedTaskfile))
+					return
+				}
+				for ext, sig := range tt.signatures {
+					if r.URL.Path == "/Taskfile.yml"+ext {
+						_, _ = w.Write(sig)
+						return
+					}
+				}
+				http.NotFound(w, r)
+			}))
+			defer srv.Close()
+
+			node, err := NewHTTPNode(srv.URL+"/Taskfile.yml", "", true)
+			require.NoError(t, err)
+			b, err := node.ReadContext(context.Background())
+			require.NoError(t, err)
+
+			signed, err := verifySignature(context.Background(), node, b, keys)
+			if tt.err != "" {
+				_, ok := errors.AsType[*errors.TaskfileSignatureInvalidError](err)
+				assert.True(t, ok, err)
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.signed, signed)
+		})
+	}
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseTrustedKeys([]string{"not a key"})
+	require.Error(t, err)
+	_, err = parseTrustedKeys([]string{"ssh-ed25519 not-base64"})
+	require.Error(t, err)
+}
@@ -60,12 +60,8 @@ func NewSnippet(b []byte, opts ...SnippetOption) *Snippet {
 	snippet.Options(opts...)
 
 	// Syntax highlight the input and split it into lines
-	buf := &bytes.Buffer{}
-	if err := quick.Highlight(buf, string(b), "yaml", "terminal", "task"); err != nil {
-		buf.Write(b)
-	}
 	linesRaw := strings.Split(string(b), "\n")
-	linesHighlighted := strings.Split(buf.String(), "\n")
+	linesHighlighted := highlight(b)
 
 	// Work out the start and end lines of the snippet
 	snippet.start = max(snippet.line-snippet.padding, 1)
@@ -178,6 +174,15 @@ func (s *Snippet) String() string {
 	return buf.String()
 }
 
+// highlight syntax highlights the Taskfile b and splits it into lines.
+func highlight(b []byte) []string {
+	buf := &bytes.Buffer{}
+	if err := quick.Highlight(buf, string(b), "yaml", "terminal", "task"); err != nil {
+		buf.Write(b)
+	}
+	return strings.Split(buf.String(), "\n")
+}
+
 func digits(number int) int {
 	count := 0
 	for number != 0 {
@@ -59,10 +59,7 @@ func RemoteExists(ctx context.Context, u url.URL, client *http.Client) (*url.URL
 	// URL The content type check is to avoid downloading files that are not
 	// Taskfiles It means we can try other files instead of downloading
 	// something that is definitely not a Taskfile
-	contentType := resp.Header.Get("Content-Type")
-	if resp.StatusCode == http.StatusOK && slices.ContainsFunc(allowedContentTypes, func(s string) bool {
-		return strings.Contains(contentType, s)
-	}) {
+	if resp.StatusCode == http.StatusOK && isTaskfileContentType(resp.Header.Get("Content-Type")) {
 		return &u, nil
 	}
 
@@ -92,3 +89,11 @@ func RemoteExists(ctx context.Context, u url.URL, client *http.Client) (*url.URL
 
 	return nil, errors.TaskfileNotFoundError{URI: u.Redacted(), Walk: false}
 }
+
+// isTaskfileContentType reports whether a response with the given content type
+// can be a Taskfile.
+func isTaskfileContentType(contentType string) bool {
+	return slices.ContainsFunc(allowedContentTypes, func(s string) bool {
+		return strings.Contains(contentType, s)
+	})
+}
@@ -10,17 +10,18 @@ import (
 )
 
 type TaskRC struct {
-	Version      *semver.Version `yaml:"version"`
-	Verbose      *bool           `yaml:"verbose"`
-	Silent       *bool           `yaml:"silent"`
-	Color        *bool           `yaml:"color"`
-	DisableFuzzy *bool           `yaml:"disable-fuzzy"`
-	Concurrency  *int            `yaml:"concurrency"`
-	Interactive  *bool           `yaml:"interactive"`
-	Remote       Remote          `yaml:"remote"`
-	Failfast     bool            `yaml:"failfast"`
-	TempDir      *string         `yaml:"temp-dir"`
-	Experiments  map[string]int  `yaml:"experiments"`
+	Version       *semver.Version `yaml:"version"`
+	Verbose       *bool           `yaml:"verbose"`
+	Silent        *bool           `yaml:"silent"`
+	Color         *bool           `yaml:"color"`
+	DisableFuzzy  *bool           `yaml:"disable-fuzzy"`
+	Concurrency   *int            `yaml:"concurrency"`
+	Interactive   *bool           `yaml:"interactive"`
+	Remote        Remote          `yaml:"remote"`
+	ArtifactCache ArtifactCache   `yaml:"artifact-cache"`
+	Failfast      bool            `yaml:"failfast"`
+	TempDir       *string         `yaml:"temp-dir"`
+	Experiments   map[string]int  `yaml:"experiments"`
 }
 
 type Remote struct {
@@ -30,9 +31,28 @@ type Remote struct {
 	CacheExpiry  *time.Duration `yaml:"cache-expiry"`
 	CacheDir     *string        `yaml:"cache-dir"`
 	TrustedHosts []string       `yaml:"trusted-hosts"`
+	TrustedKeys  []string       `yaml:"trusted-keys"`
 	CACert       *string        `yaml:"cacert"`
 	Cert         *string        `yaml:"cert"`
 	CertKey      *string        `yaml:"cert-key"`
+	// Auth holds the credentials sent to the hosts of remote Taskfiles, keyed
+	// by host, with an optional port.
+	Auth map[string]*RemoteAuth `yaml:"auth"`
+}
+
+// RemoteAuth holds the credentials sent to a host of remote Taskfiles. Its
+// values can reference environment variables, e.g. "$TOKEN".
+type RemoteAuth struct {
+	Token    string `yaml:"token"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// ArtifactCache configures the shared HTTP cache of generated files. It uses
+// the TLS settings of [Remote].
+type ArtifactCache struct {
+	URL  *string `yaml:"url"`
+	Mode *string `yaml:"mode"`
 }
 
 // Merge combines the current TaskRC with another TaskRC, prioritizing non-nil fields from the other TaskRC.
@@ -60,9 +80,22 @@ func (t *TaskRC) Merge(other *TaskRC) {
 		slices.Sort(merged)
 		t.Remote.TrustedHosts = slices.Compact(merged)
 	}
+	if len(other.Remote.TrustedKeys) > 0 {
+		merged := slices.Concat(other.Remote.TrustedKeys, t.Remote.TrustedKeys)
+		slices.Sort(merged)
+		t.Remote.TrustedKeys = slices.Compact(merged)
+	}
 	t.Remote.CACert = cmp.Or(other.Remote.CACert, t.Remote.CACert)
 	t.Remote.Cert = cmp.Or(other.Remote.Cert, t.Remote.Cert)
 	t.Remote.CertKey = cmp.Or(other.Remote.CertKey, t.Remote.CertKey)
+	if t.Remote.Auth == nil && other.Remote.Auth != nil {
+		t.Remote.Auth = other.Remote.Auth
+	} else if t.Remote.Auth != nil && other.Remote.Auth != nil {
+		maps.Copy(t.Remote.Auth, other.Remote.Auth)
+	}
+
+	t.ArtifactCache.URL = cmp.Or(other.ArtifactCache.URL, t.ArtifactCache.URL)
+	t.ArtifactCache.Mode = cmp.Or(other.ArtifactCache.Mode, t.ArtifactCache.Mode)
 
 	t.Verbose = cmp.Or(other.Verbose, t.Verbose)
 	t.Silent = cmp.Or(other.Silent, t.Silent)
@@ -341,3 +341,54 @@ remote:
 		assert.Equal(t, []string{"github.com", "gitlab.com"}, base.Remote.TrustedHosts)
 	})
 }
+
+func TestGetConfig_ArtifactCacheMerge(t *testing.T) { //nolint:paralleltest // cannot run in parallel
+	_, homeDir, localDir := setupDirs(t)
+
+	writeFile(t, homeDir, ".taskrc.yml", `
+artifact-cache:
+  url: https://cache.example.com/ac
+  mode: read-write
+`)
+	writeFile(t, localDir, ".taskrc.yml", `
+artifact-cache:
+  mode: read-only
+`)
+
+	cfg, err := GetConfig(localDir)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	require.NotNil(t, cfg.ArtifactCache.URL)
+	require.NotNil(t, cfg.ArtifactCache.Mode)
+	assert.Equal(t, "https://cache.example.com/ac", *cfg.ArtifactCache.URL)
+	assert.Equal(t, "read-only", *cfg.ArtifactCache.Mode)
+}
+
+func TestGetConfig_RemoteAuthMerge(t *testing.T) { //nolint:paralleltest // cannot run in parallel
+	_, homeDir, localDir := setupDirs(t)
+
+	writeFile(t, homeDir, ".taskrc.yml", `
+remote:
+  auth:
+    artifacts.example.com:
+      token: $HOME_TOKEN
+    example.com:
+      username: home
+      password: $HOME_PASSWORD
+`)
+	writeFile(t, localDir, ".taskrc.yml", `
+remote:
+  auth:
+    example.com:
+      username: local
+      password: $LOCAL_PASSWORD
+`)
+
+	cfg, err := GetConfig(localDir)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, map[string]*ast.RemoteAuth{
+		"artifacts.example.com": {Token: "$HOME_TOKEN"},
+		"example.com":           {Username: "local", Password: "$LOCAL_PASSWORD"},
+	}, cfg.Remote.Auth)
+}
@@ -1,10 +1,12 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -29,6 +31,33 @@ func (e *Executor) shouldTaskUseGitignore(t *ast.Task) bool {
 	return e.Taskfile.UseGitignore != nil && *e.Taskfile.UseGitignore
 }
 
+// shouldTaskFingerprintTask resolves whether the definition of a task is part
+// of its checksum, with the same precedence as shouldTaskUseGitignore.
+func (e *Executor) shouldTaskFingerprintTask(t *ast.Task) bool {
+	if t.FingerprintTask != nil {
+		return *t.FingerprintTask
+	}
+	return e.Taskfile.FingerprintTask != nil && *e.Taskfile.FingerprintTask
+}
+
+// shouldTaskVerifyGenerates resolves whether the generated files of a task are
+// verified, with the same precedence as shouldTaskUseGitignore.
+func (e *Executor) shouldTaskVerifyGenerates(t *ast.Task) bool {
+	if t.VerifyGenerates != nil {
+		return *t.VerifyGenerates
+	}
+	return e.Taskfile.VerifyGenerates != nil && *e.Taskfile.VerifyGenerates
+}
+
+// shouldTaskDependOnDeps resolves whether a task is stale whenever one of its
+// deps ran, with the same precedence as shouldTaskUseGitignore.
+func (e *Executor) shouldTaskDependOnDeps(t *ast.Task) bool {
+	if t.DependsOnDeps != nil {
+		return *t.DependsOnDeps
+	}
+	return e.Taskfile.DependsOnDeps != nil && *e.Taskfile.DependsOnDeps
+}
+
 // CompiledTask returns a copy of a task, but replacing variables in almost all
 // properties using the Go template package.
 func (e *Executor) CompiledTask(call *Call) (*ast.Task, error) {
@@ -52,8 +81,19 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 	}
 
 	cache := &templater.Cache{Vars: vars}
+	if cache.Dir, err = e.taskDir(origTask, cache); err != nil {
+		return nil, err
+	}
+
+	sources, err := e.resolveSourceTasks(origTask, origTask.Sources)
+	if err != nil {
+		return nil, err
+	}
 
 	gitignore := e.shouldTaskUseGitignore(origTask)
+	fingerprintTask := e.shouldTaskFingerprintTask(origTask)
+	verifyGenerates := e.shouldTaskVerifyGenerates(origTask)
+	dependsOnDeps := e.shouldTaskDependOnDeps(origTask)
 
 	return &ast.Task{
 		Task:                 origTask.Task,
@@ -62,7 +102,7 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 		Prompt:               templater.Replace(origTask.Prompt, cache),
 		Summary:              templater.Replace(origTask.Summary, cache),
 		Aliases:              origTask.Aliases,
-		Sources:              origTask.Sources,
+		Sources:              sources,
 		Generates:            origTask.Generates,
 		Dir:                  origTask.Dir,
 		Set:                  origTask.Set,
@@ -72,6 +112,9 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 		Dotenv:               origTask.Dotenv,
 		Silent:               deepcopy.Scalar(origTask.Silent),
 		UseGitignore:         &gitignore,
+		FingerprintTask:      &fingerprintTask,
+		VerifyGenerates:      &verifyGenerates,
+		DependsOnDeps:        &dependsOnDeps,
 		Interactive:          origTask.Interactive,
 		Internal:             origTask.Internal,
 		Method:               origTask.Method,
@@ -80,9 +123,12 @@ func (e *Executor) CompiledTaskForTaskList(call *Call) (*ast.Task, error) {
 		Run:                  origTask.Run,
 		IncludeVars:          origTask.IncludeVars,
 		IncludedTaskfileVars: origTask.IncludedTaskfileVars,
+		CallVars:             callVars(call, vars),
 		Platforms:            origTask.Platforms,
 		Location:             origTask.Location,
+		TaskfileDir:          origTask.TaskfileDir,
 		Requires:             origTask.Requires,
+		Args:                 origTask.Args,
 		Watch:                origTask.Watch,
 		Namespace:            origTask.Namespace,
 		Failfast:             origTask.Failfast,
@@ -112,18 +158,24 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 	}
 
 	cache := &templater.Cache{Vars: vars}
+	if cache.Dir, err = e.taskDir(origTask, cache); err != nil {
+		return nil, err
+	}
 
 	// Resolve enum refs only when dynamic variables have been evaluated,
 	// since enum refs may depend on shell-derived variables (e.g. fromJson)
-	requires := origTask.Requires
+	requires, args := origTask.Requires, origTask.Args
 	if evaluateShVars {
-		requires = origTask.Requires.DeepCopy()
-		if err := resolveEnumRefs(requires, cache); err != nil {
+		requires, args = origTask.Requires.DeepCopy(), deepcopy.Slice(origTask.Args)
+		if err := resolveEnumRefs((&ast.Task{Requires: requires, Args: args}).RequiredVars(), cache); err != nil {
 			return nil, err
 		}
 	}
 
 	gitignore := e.shouldTaskUseGitignore(origTask)
+	fingerprintTask := e.shouldTaskFingerprintTask(origTask)
+	verifyGenerates := e.shouldTaskVerifyGenerates(origTask)
+	dependsOnDeps := e.shouldTaskDependOnDeps(origTask)
 
 	new := ast.Task{
 		Task:                 origTask.Task,
@@ -134,7 +186,7 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		Aliases:              origTask.Aliases,
 		Sources:              templater.ReplaceGlobs(origTask.Sources, cache),
 		Generates:            templater.ReplaceGlobs(origTask.Generates, cache),
-		Dir:                  templater.Replace(origTask.Dir, cache),
+		Dir:                  cache.Dir,
 		Set:                  origTask.Set,
 		Shopt:                origTask.Shopt,
 		Vars:                 vars,
@@ -142,6 +194,9 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		Dotenv:               templater.Replace(origTask.Dotenv, cache),
 		Silent:               deepcopy.Scalar(origTask.Silent),
 		UseGitignore:         &gitignore,
+		FingerprintTask:      &fingerprintTask,
+		VerifyGenerates:      &verifyGenerates,
+		DependsOnDeps:        &dependsOnDeps,
 		Interactive:          origTask.Interactive,
 		Internal:             origTask.Internal,
 		Method:               templater.Replace(origTask.Method, cache),
@@ -150,25 +205,26 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 		Run:                  templater.Replace(origTask.Run, cache),
 		IncludeVars:          origTask.IncludeVars,
 		IncludedTaskfileVars: origTask.IncludedTaskfileVars,
+		CallVars:             callVars(call, vars),
+		UsesSourceChanges:    origTask.ReferencesSourceChangesVars(),
 		Platforms:            origTask.Platforms,
 		If:                   templater.Replace(origTask.If, cache),
 		Location:             origTask.Location,
+		TaskfileDir:          origTask.TaskfileDir,
 		Requires:             requires,
+		Args:                 args,
 		Watch:                origTask.Watch,
 		Failfast:             origTask.Failfast,
 		Namespace:            origTask.Namespace,
 		FullName:             fullName,
 	}
-	new.Dir, err = execext.ExpandLiteral(new.Dir)
-	if err != nil {
-		return nil, err
-	}
-	if e.Dir != "" {
-		new.Dir = filepathext.SmartJoin(e.Dir, new.Dir)
-	}
 	if new.Prefix == "" {
 		new.Prefix = new.Task
 	}
+	new.Sources, err = e.resolveSourceTasks(origTask, new.Sources)
+	if err != nil {
+		return nil, err
+	}
 
 	dotenvEnvs := ast.NewVars()
 	if len(new.Dotenv) > 0 {
@@ -215,7 +271,7 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 			// An invalid method must not fail compilation: --force skips
 			// fingerprinting altogether, and the up-to-date check reports it
 			// on every other path.
-			value, err := fingerprinter.SourceValue(&new)
+			value, err := fingerprinter.SourceValue(context.Background(), &new)
 			if err != nil && !errors.Is(err, fingerprint.ErrInvalidMethod) {
 				return nil, err
 			}
@@ -227,6 +283,16 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 				cache.ResetCache()
 			}
 		}
+		if new.UsesSourceChanges {
+			changes, err := fingerprinter.SourceChanges(&new)
+			if err != nil {
+				return nil, err
+			}
+			vars.Set("CHANGED_SOURCES", ast.Var{Value: changes.Changed})
+			vars.Set("ADDED_SOURCES", ast.Var{Value: changes.Added})
+			vars.Set("REMOVED_SOURCES", ast.Var{Value: changes.Removed})
+			cache.ResetCache()
+		}
 	}
 
 	if len(origTask.Cmds) > 0 {
@@ -347,6 +413,80 @@ func (e *Executor) compiledTask(call *Call, evaluateShVars bool) (*ast.Task, err
 	return &new, nil
 }
 
+// taskDir returns the directory of t, templated with the vars of cache and
+// joined to the directory of the executor.
+func (e *Executor) taskDir(t *ast.Task, cache *templater.Cache) (string, error) {
+	dir, err := execext.ExpandLiteral(templater.Replace(t.Dir, cache))
+	if err != nil {
+		return "", err
+	}
+	if e.Dir != "" {
+		dir = filepathext.SmartJoin(e.Dir, dir)
+	}
+	return dir, nil
+}
+
+// resolveSourceTasks replaces the sources of t referencing another task with
+// the generates of that task, made relative to its directory.
+func (e *Executor) resolveSourceTasks(t *ast.Task, sources []*ast.Glob) ([]*ast.Glob, error) {
+	if !slices.ContainsFunc(sources, func(g *ast.Glob) bool { return g.Task != "" }) {
+		return sources, nil
+	}
+
+	resolved := make([]*ast.Glob, 0, len(sources))
+	for _, g := range sources {
+		if g.Task == "" {
+			resolved = append(resolved, g)
+			continue
+		}
+
+		call := &Call{Task: g.Task}
+		origTask, err := e.GetTask(call)
+		if err != nil {
+			return nil, err
+		}
+		if len(origTask.Generates) == 0 {
+			return nil, fmt.Errorf("task: Task %q has no generates to use as sources of %q", g.Task, t.Task)
+		}
+		vars, err := e.Compiler.FastGetVariables(origTask, call)
+		if err != nil {
+			return nil, err
+		}
+		cache := &templater.Cache{Vars: vars}
+		dir, err := e.taskDir(origTask, cache)
+		if err != nil {
+			return nil, err
+		}
+		generates := templater.ReplaceGlobs(origTask.Generates, cache)
+		if err := cache.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, generate := range generates {
+			resolved = append(resolved, &ast.Glob{
+				Glob:   filepathext.SmartJoin(dir, generate.Glob),
+				Negate: generate.Negate,
+			})
+		}
+	}
+	return resolved, nil
+}
+
+// callVars returns the resolved values of the vars a task was called with,
+// including the ones of the current iteration of a for loop.
+func callVars(call *Call, vars *ast.Vars) *ast.Vars {
+	result := ast.NewVars()
+	if call.Vars == nil {
+		return result
+	}
+	for name := range call.Vars.Keys() {
+		if v, ok := vars.Get(name); ok {
+			result.Set(name, v)
+		}
+	}
+	return result
+}
+
 func asAnySlice[T any](slice []T) []any {
 	ret := make([]any, len(slice))
 	for i, v := range slice {
@@ -502,11 +642,8 @@ func resolveMatrixRefs(matrix *ast.Matrix, cache *templater.Cache) (*ast.Matrix,
 	return resolved, nil
 }
 
-func resolveEnumRefs(requires *ast.Requires, cache *templater.Cache) error {
-	if requires == nil || len(requires.Vars) == 0 {
-		return nil
-	}
-	for _, v := range requires.Vars {
+func resolveEnumRefs(vars []*ast.VarsWithValidation, cache *templater.Cache) error {
+	for _, v := range vars {
 		if v.Enum == nil || v.Enum.Ref == "" {
 			continue
 		}
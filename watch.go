@@ -82,6 +82,7 @@ func (e *Executor) watchTasks(calls ...*Call) error {
 				ctx, cancel = context.WithCancel(context.Background())
 
 				e.Compiler.ResetCache()
+				e.fingerprintCache.InvalidateGlobs()
 
 				for _, c := range calls {
 					go func() {